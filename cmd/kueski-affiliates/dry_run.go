@@ -0,0 +1,71 @@
+package main
+
+import (
+  "encoding/json"
+  "flag"
+  "fmt"
+  "io"
+  "io/ioutil"
+  "os"
+
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski"
+)
+
+// dryRun - Prints the requests an evaluation would send, without sending them.
+func dryRun(args []string, stdout io.Writer) error {
+  flags := flag.NewFlagSet("dry-run", flag.ContinueOnError)
+  url := flags.String("url", os.Getenv("KUESKI_URL"), "Kueski API host URL")
+  curp := flags.String("curp", "", "lead CURP")
+  email := flags.String("email", "", "lead email")
+  fullDataFile := flags.String("full-data", "", "JSON file with the lead full data, - for stdin")
+
+  if err := flags.Parse(args); err != nil {
+    return err
+  }
+
+  fullData, err := readFullData(*fullDataFile)
+
+  if err != nil {
+    return err
+  }
+
+  client := kueski.NewClient(*url, os.Getenv("KUESKI_API_KEY"), os.Getenv("KUESKI_SECRET_KEY"))
+  requests, err := client.DryRun(*curp, *email, fullData)
+
+  if err != nil {
+    return err
+  }
+
+  for _, request := range requests {
+    fmt.Fprintf(stdout, "%s\n\n", request)
+  }
+
+  return nil
+}
+
+// readFullData - Decodes the full data JSON from path, nil when no path is given.
+func readFullData(path string) (interface{}, error) {
+  var blob []byte
+  var err error
+
+  switch path {
+  case "":
+    return nil, nil
+  case "-":
+    blob, err = ioutil.ReadAll(os.Stdin)
+  default:
+    blob, err = ioutil.ReadFile(path)
+  }
+
+  if err != nil {
+    return nil, err
+  }
+
+  var fullData interface{}
+
+  if err := json.Unmarshal(blob, &fullData); err != nil {
+    return nil, fmt.Errorf("invalid full data JSON: %v", err)
+  }
+
+  return fullData, nil
+}
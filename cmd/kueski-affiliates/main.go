@@ -0,0 +1,56 @@
+// kueski-affiliates - Command line tools for the Kueski Affiliates API client.
+//
+// Usage:
+//   kueski-affiliates <command> [flags]
+//
+// The API credentials are read from the KUESKI_API_KEY and KUESKI_SECRET_KEY environment variables.
+package main
+
+import (
+  "fmt"
+  "io"
+  "os"
+  "sort"
+)
+
+// command - A subcommand: parses its own flags and writes its output to stdout.
+type command func(args []string, stdout io.Writer) error
+
+var commands = map[string]command{
+  "dry-run":   dryRun,
+  "reconcile": reconcileReport,
+}
+
+func main() {
+  os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+  if len(args) == 0 || commands[args[0]] == nil {
+    usage(stderr)
+    return 2
+  }
+
+  if err := commands[args[0]](args[1:], stdout); err != nil {
+    fmt.Fprintf(stderr, "%s: %v\n", args[0], err)
+    return 1
+  }
+
+  return 0
+}
+
+func usage(stderr io.Writer) {
+  names := []string{}
+
+  for name := range commands {
+    names = append(names, name)
+  }
+
+  sort.Strings(names)
+  fmt.Fprintln(stderr, "usage: kueski-affiliates <command> [flags]")
+  fmt.Fprintln(stderr, "commands:")
+
+  for _, name := range names {
+    fmt.Fprintf(stderr, "  %s\n", name)
+  }
+}
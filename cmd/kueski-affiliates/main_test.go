@@ -0,0 +1,73 @@
+package main
+
+import (
+  "bytes"
+  "io/ioutil"
+  "os"
+  "path/filepath"
+  "strings"
+  "testing"
+
+  "github.com/stretchr/testify/assert"
+)
+
+func TestRunUsage(t *testing.T) {
+  stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+
+  assert.Equal(t, 2, run([]string{"unknown"}, stdout, stderr))
+  assert.Contains(t, stderr.String(), "dry-run")
+}
+
+func TestDryRun(t *testing.T) {
+  dir, _ := ioutil.TempDir("", "dry-run")
+  defer os.RemoveAll(dir)
+  fullDataFile := filepath.Join(dir, "full_data.json")
+  ioutil.WriteFile(fullDataFile, []byte(`{ "name": "Lead" }`), 0600)
+
+  stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+  args := []string{"dry-run", "-url", "http://kueski.com", "-curp", "BADD110313HCMLNS06", "-email", "e@mail.com", "-full-data", fullDataFile}
+
+  assert.Equal(t, 0, run(args, stdout, stderr))
+  assert.Equal(t, 3, strings.Count(stdout.String(), "POST http://kueski.com/affiliates/"))
+  assert.Contains(t, stdout.String(), `"full_data":{"name":"Lead"}`)
+  assert.NotContains(t, stdout.String(), "Secret")
+
+  stdout, stderr = &bytes.Buffer{}, &bytes.Buffer{}
+  args = []string{"dry-run", "-url", "http://kueski.com", "-curp", "BADD110313HCMLNS06", "-email", "e@mail.com"}
+
+  assert.Equal(t, 1, run(args, stdout, stderr))
+  assert.Equal(t, "dry-run: MissingFullData\n", stderr.String())
+}
+
+func TestReconcile(t *testing.T) {
+  dir, _ := ioutil.TempDir("", "reconcile")
+  defer os.RemoveAll(dir)
+
+  reportFile := filepath.Join(dir, "report.csv")
+  ioutil.WriteFile(reportFile, []byte("request_id,status,amount\n1,funded,250\n2,funded,250\n9,approved,80.5\n1,funded,250\n8,rejected,-2.5\n"), 0600)
+  conversionsFile := filepath.Join(dir, "conversions.json")
+  ioutil.WriteFile(conversionsFile, []byte(`{
+    "1": { "request_id": "1", "status": "funded" },
+    "2": { "request_id": "2", "status": "approved" },
+    "3": { "request_id": "3", "status": "approved" }
+  }`), 0600)
+
+  stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+  args := []string{"reconcile", "-report", reportFile, "-conversions", conversionsFile}
+
+  assert.Equal(t, 0, run(args, stdout, stderr))
+  assert.Equal(t, "matched: 1\nstatus mismatch: 1\n  2 ours=approved theirs=funded\n"+
+    "missing on their side: 1\n  3 approved\nmissing on our side: 2\n  8 rejected -2.50\n  9 approved 80.50\n"+
+    "duplicate credits: 2\n  1 funded 250.00\n  1 funded 250.00\n", stdout.String())
+
+  stdout = &bytes.Buffer{}
+  assert.Equal(t, 0, run(append(args, "-json"), stdout, stderr))
+  assert.Contains(t, stdout.String(), `"missing_ours": [`)
+
+  assert.Equal(t, 1, run([]string{"reconcile", "-report", reportFile}, stdout, stderr))
+
+  stderr = &bytes.Buffer{}
+  missing := filepath.Join(dir, "mistyped.json")
+  assert.Equal(t, 1, run([]string{"reconcile", "-report", reportFile, "-conversions", missing}, stdout, stderr))
+  assert.Contains(t, stderr.String(), "mistyped.json")
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+  "encoding/json"
+  "flag"
+  "fmt"
+  "io"
+  "os"
+  "path/filepath"
+  "strings"
+
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski/commission"
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski/reconcile"
+)
+
+// reconcileReport - Matches a Kueski settlement report against the conversions recorded by a commission.FileStore.
+func reconcileReport(args []string, stdout io.Writer) error {
+  flags := flag.NewFlagSet("reconcile", flag.ContinueOnError)
+  reportFile := flags.String("report", "", "Kueski settlement report, CSV or JSON")
+  format := flags.String("format", "", "report format, csv or json; guessed from the file extension when empty")
+  conversionsFile := flags.String("conversions", "", "conversions file of commission.NewFileStore")
+  asJSON := flags.Bool("json", false, "print the result as JSON")
+
+  if err := flags.Parse(args); err != nil {
+    return err
+  }
+
+  if *reportFile == "" || *conversionsFile == "" {
+    return fmt.Errorf("-report and -conversions are required")
+  }
+
+  credits, err := readReport(*reportFile, *format)
+
+  if err != nil {
+    return err
+  }
+
+  // NewFileStore starts empty without the file, which would report every credit as missing on our side.
+  if _, err := os.Stat(*conversionsFile); err != nil {
+    return err
+  }
+
+  store, err := commission.NewFileStore(*conversionsFile)
+
+  if err != nil {
+    return err
+  }
+
+  conversions, err := store.List()
+
+  if err != nil {
+    return err
+  }
+
+  result := reconcile.Reconcile(reconcile.FromConversions(conversions), credits)
+
+  if *asJSON {
+    encoder := json.NewEncoder(stdout)
+    encoder.SetIndent("", "  ")
+    return encoder.Encode(result)
+  }
+
+  printResult(stdout, result)
+  return nil
+}
+
+func readReport(path, format string) ([]reconcile.Credit, error) {
+  file, err := os.Open(path)
+
+  if err != nil {
+    return nil, err
+  }
+
+  defer file.Close()
+
+  if format == "" {
+    format = strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+  }
+
+  if format == "json" {
+    return reconcile.ParseJSON(file)
+  }
+
+  return reconcile.ParseCSV(file)
+}
+
+func printResult(stdout io.Writer, result reconcile.Result) {
+  fmt.Fprintf(stdout, "matched: %d\n", len(result.Matched))
+  fmt.Fprintf(stdout, "status mismatch: %d\n", len(result.StatusMismatch))
+
+  for _, match := range result.StatusMismatch {
+    fmt.Fprintf(stdout, "  %s ours=%s theirs=%s\n", match.Submission.RequestID, match.Submission.Status, match.Credit.Status)
+  }
+
+  fmt.Fprintf(stdout, "missing on their side: %d\n", len(result.MissingTheirs))
+
+  for _, submission := range result.MissingTheirs {
+    fmt.Fprintf(stdout, "  %s %s\n", submission.RequestID, submission.Status)
+  }
+
+  fmt.Fprintf(stdout, "missing on our side: %d\n", len(result.MissingOurs))
+
+  for _, credit := range result.MissingOurs {
+    fmt.Fprintf(stdout, "  %s %s %s\n", credit.RequestID, credit.Status, formatCents(credit.Amount))
+  }
+
+  fmt.Fprintf(stdout, "duplicate credits: %d\n", len(result.Duplicates))
+
+  for _, credit := range result.Duplicates {
+    fmt.Fprintf(stdout, "  %s %s %s\n", credit.RequestID, credit.Status, formatCents(credit.Amount))
+  }
+}
+
+// formatCents - Amount in cents as pesos, e.g. -2.50 for -250.
+func formatCents(amount int64) string {
+  sign := ""
+
+  if amount < 0 {
+    sign = "-"
+    amount = -amount
+  }
+
+  return fmt.Sprintf("%s%d.%02d", sign, amount/100, amount%100)
+}
@@ -1,12 +1,11 @@
 package kueski
 
 import (
-  "crypto/hmac"
-  "crypto/sha1"
-  "encoding/base64"
+  "context"
   "fmt"
   "time"
 
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski/breaker"
   "github.com/KueskiEngineering/go_affiliate-marketing/kueski/errors"
   "github.com/KueskiEngineering/go_affiliate-marketing/kueski/util"
 )
@@ -38,29 +37,52 @@ type TokenAccessor interface {
 
 // AuthorizationToken - Creates a signature for API authentication.
 func (client *Client) AuthorizationToken(canonical string) string {
-  keyForSign := []byte(client.secretKey)
-  h := hmac.New(sha1.New, keyForSign)
-  h.Write([]byte(canonical))
-  return client.apiKey + ":" + base64.StdEncoding.EncodeToString(h.Sum(nil))
+  return client.apiKey + ":" + util.Sign(client.secretKey, canonical)
+}
+
+// contextTokenAccessor - TokenAccessor that keeps the context of the request needing the token
+// and the host it is addressed to.
+type contextTokenAccessor struct {
+  client *Client
+  ctx    context.Context
+  host   *apiHost
 }
 
 // RequestToken - Internal function to retrieve a valid JWT from Kueski API
 func (client *Client) RequestToken() ([]byte, error) {
-  url := util.BuildURL(client.url, AuthenticatePath)
-  body := []byte(BodyString)
-  now := time.Now()
-  httpDate := util.HTTPDate(now)
+  return client.requestToken(context.Background(), client.primaryHost())
+}
 
-  canonical := util.Canonical(Method, ApplicationJSON, BodyString, fmt.Sprintf("/%s", AuthenticatePath), now)
+func (accessor *contextTokenAccessor) RequestToken() ([]byte, error) {
+  return accessor.client.requestToken(accessor.ctx, accessor.host)
+}
 
-  headers := map[string]string{
-    ContentMD5:    util.ContentMD5(BodyString),
-    Authorization: fmt.Sprintf("%s %s", APIAuthPrefix, client.AuthorizationToken(canonical)),
-    Date:          httpDate,
-    ContentType:   ApplicationJSON,
+func (accessor *contextTokenAccessor) tokenRefreshed(expiration time.Time) {
+  accessor.client.tokenRefreshed(expiration)
+}
+
+func (client *Client) requestToken(ctx context.Context, host *apiHost) ([]byte, error) {
+  url := util.BuildURL(host.url, AuthenticatePath)
+  client.log().Debug("kueski token request", "url", url)
+
+  responseBody, err := client.authenticate(ctx, client.breakers[host.url], url)
+
+  if err != nil {
+    client.log().Error("kueski token request failed", "url", url, "error", err)
   }
 
-  response, err := client.requester(url, headers, body)
+  return responseBody, err
+}
+
+func (client *Client) authenticate(ctx context.Context, circuit *breaker.Breaker, url string) ([]byte, error) {
+  body := []byte(BodyString)
+  headers := client.authenticationHeaders(time.Now())
+
+  response, _, err := client.do(ctx, circuit, &util.Request{Method: Method, URL: url, Headers: headers}, body)
+
+  if err == errors.CircuitOpen || err == errors.TLSHandshakeFailed {
+    return nil, err
+  }
 
   if err != nil {
     return nil, errors.UnableToRefreshJWT
@@ -74,7 +96,7 @@ func (client *Client) RequestToken() ([]byte, error) {
     return nil, errors.AccessDenied
   }
 
-  responseBody, err := util.ExtractBody(response)
+  responseBody, err := response.ReadBody()
 
   if err != nil {
     return nil, errors.InvalidJWTResponseFormat
@@ -82,3 +104,15 @@ func (client *Client) RequestToken() ([]byte, error) {
 
   return responseBody, nil
 }
+
+// authenticationHeaders - Signed headers of the authentication request made at now.
+func (client *Client) authenticationHeaders(now time.Time) map[string]string {
+  canonical := util.Canonical(Method, ApplicationJSON, BodyString, fmt.Sprintf("/%s", AuthenticatePath), now)
+
+  return map[string]string{
+    ContentMD5:    util.ContentMD5(BodyString),
+    Authorization: fmt.Sprintf("%s %s", APIAuthPrefix, client.AuthorizationToken(canonical)),
+    Date:          util.HTTPDate(now),
+    ContentType:   ApplicationJSON,
+  }
+}
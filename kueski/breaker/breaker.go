@@ -0,0 +1,168 @@
+package breaker
+
+import (
+  "sync"
+  "time"
+
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski/errors"
+)
+
+// State - Circuit breaker state.
+type State int
+
+// Closed   - Requests flow normally and failures are counted.
+// HalfOpen - A limited number of trial requests probe whether the API recovered.
+// Open     - Requests fail fast until the open timeout elapses.
+const (
+  Closed   State = 0
+  HalfOpen State = 1
+  Open     State = 2
+)
+
+var stateNames = map[State]string{Closed: "closed", HalfOpen: "half-open", Open: "open"}
+
+func (state State) String() string {
+  return stateNames[state]
+}
+
+// Settings - Circuit breaker configuration.
+// FailureThreshold - Consecutive failures that trip the breaker, 5 when zero.
+// OpenTimeout      - Time the breaker stays open before probing, 30 seconds when zero.
+// HalfOpenRequests - Successful trial requests needed to close the breaker again, 1 when zero.
+// OnStateChange    - Optional callback for every transition.
+type Settings struct {
+  FailureThreshold int
+  OpenTimeout      time.Duration
+  HalfOpenRequests int
+  OnStateChange    func(from, to State)
+}
+
+// Breaker - Circuit breaker: trips after repeated failures and fails fast while open.
+type Breaker struct {
+  settings  Settings
+  state     State
+  failures  int
+  trials    int
+  successes int
+  openedAt  time.Time
+  now       func() time.Time
+  sync.Mutex
+}
+
+// New - Breaker constructor, starting closed.
+func New(settings Settings) *Breaker {
+  if settings.FailureThreshold <= 0 {
+    settings.FailureThreshold = 5
+  }
+
+  if settings.OpenTimeout <= 0 {
+    settings.OpenTimeout = 30 * time.Second
+  }
+
+  if settings.HalfOpenRequests <= 0 {
+    settings.HalfOpenRequests = 1
+  }
+
+  return &Breaker{settings: settings, now: time.Now}
+}
+
+// State - Current state, moving from open to half-open if the open timeout elapsed.
+func (breaker *Breaker) State() State {
+  breaker.Lock()
+  transition := breaker.refresh()
+  state := breaker.state
+  breaker.Unlock()
+
+  breaker.notify(transition)
+  return state
+}
+
+// Allow - Whether a request may be sent. Fails with CircuitOpen while open,
+// or when the trial requests of the half-open state are all in flight.
+// Every allowed request must be followed by a call to Record.
+func (breaker *Breaker) Allow() error {
+  breaker.Lock()
+  transition := breaker.refresh()
+  err := error(nil)
+
+  switch breaker.state {
+  case Open:
+    err = errors.CircuitOpen
+  case HalfOpen:
+    if breaker.trials >= breaker.settings.HalfOpenRequests {
+      err = errors.CircuitOpen
+    } else {
+      breaker.trials++
+    }
+  }
+
+  breaker.Unlock()
+
+  breaker.notify(transition)
+  return err
+}
+
+// Release - Gives back an allowed request that was finally not sent, without recording an outcome.
+func (breaker *Breaker) Release() {
+  breaker.Lock()
+  defer breaker.Unlock()
+
+  if breaker.state == HalfOpen && breaker.trials > 0 {
+    breaker.trials--
+  }
+}
+
+// Record - Reports the outcome of an allowed request.
+func (breaker *Breaker) Record(success bool) {
+  breaker.Lock()
+  var transition []State
+
+  switch breaker.state {
+  case Closed:
+    if success {
+      breaker.failures = 0
+    } else if breaker.failures++; breaker.failures >= breaker.settings.FailureThreshold {
+      transition = breaker.moveTo(Open)
+    }
+  case HalfOpen:
+    if !success {
+      transition = breaker.moveTo(Open)
+    } else if breaker.successes++; breaker.successes >= breaker.settings.HalfOpenRequests {
+      transition = breaker.moveTo(Closed)
+    }
+  }
+
+  breaker.Unlock()
+
+  breaker.notify(transition)
+}
+
+// refresh - Moves from open to half-open once the timeout elapsed. Must hold the lock.
+func (breaker *Breaker) refresh() []State {
+  if breaker.state == Open && breaker.now().Sub(breaker.openedAt) >= breaker.settings.OpenTimeout {
+    return breaker.moveTo(HalfOpen)
+  }
+
+  return nil
+}
+
+// moveTo - Changes the state resetting the counters. Must hold the lock.
+func (breaker *Breaker) moveTo(state State) []State {
+  from := breaker.state
+  breaker.state = state
+  breaker.failures = 0
+  breaker.trials = 0
+  breaker.successes = 0
+
+  if state == Open {
+    breaker.openedAt = breaker.now()
+  }
+
+  return []State{from, state}
+}
+
+func (breaker *Breaker) notify(transition []State) {
+  if transition != nil && breaker.settings.OnStateChange != nil {
+    breaker.settings.OnStateChange(transition[0], transition[1])
+  }
+}
@@ -0,0 +1,71 @@
+package breaker
+
+import (
+  "testing"
+  "time"
+
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski/errors"
+  "github.com/stretchr/testify/assert"
+)
+
+func TestBreakerTransitions(t *testing.T) {
+  now := time.Now()
+  transitions := []string{}
+  breaker := New(Settings{
+    FailureThreshold: 2,
+    OpenTimeout:      time.Minute,
+    HalfOpenRequests: 2,
+    OnStateChange:    func(from, to State) { transitions = append(transitions, from.String()+">"+to.String()) },
+  })
+  breaker.now = func() time.Time { return now }
+
+  assert.Nil(t, breaker.Allow())
+  breaker.Record(false)
+  assert.Nil(t, breaker.Allow())
+  breaker.Record(true)
+  assert.Nil(t, breaker.Allow())
+  breaker.Record(false)
+  assert.Equal(t, Closed, breaker.State())
+
+  breaker.Allow()
+  breaker.Record(false)
+  assert.Equal(t, Open, breaker.State())
+  assert.Equal(t, errors.CircuitOpen, breaker.Allow())
+
+  now = now.Add(time.Minute)
+  assert.Nil(t, breaker.Allow())
+  assert.Equal(t, HalfOpen, breaker.State())
+  assert.Nil(t, breaker.Allow())
+  assert.Equal(t, errors.CircuitOpen, breaker.Allow())
+
+  breaker.Release()
+  assert.Nil(t, breaker.Allow())
+  breaker.Record(true)
+  breaker.Record(false)
+  assert.Equal(t, Open, breaker.State())
+
+  now = now.Add(time.Minute)
+  breaker.Allow()
+  breaker.Allow()
+  breaker.Record(true)
+  breaker.Record(true)
+  assert.Equal(t, Closed, breaker.State())
+
+  assert.Equal(t, []string{"closed>open", "open>half-open", "half-open>open", "open>half-open", "half-open>closed"}, transitions)
+}
+
+func TestBreakerDefaults(t *testing.T) {
+  breaker := New(Settings{})
+
+  assert.Equal(t, 5, breaker.settings.FailureThreshold)
+  assert.Equal(t, 30*time.Second, breaker.settings.OpenTimeout)
+  assert.Equal(t, 1, breaker.settings.HalfOpenRequests)
+  assert.Equal(t, "", State(7).String())
+
+  for i := 0; i < 5; i++ {
+    breaker.Allow()
+    breaker.Record(false)
+  }
+
+  assert.Equal(t, Open, breaker.State())
+}
@@ -0,0 +1,191 @@
+// Package cassette records HTTP interactions with the Kueski API into cassette files,
+// with secrets and PII scrubbed, and replays them so tests run without network access.
+package cassette
+
+import (
+  "encoding/json"
+  "io/ioutil"
+  "os"
+  "strings"
+
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski/logging"
+)
+
+// Request - Recorded request. Body is the scrubbed body.
+type Request struct {
+  Method  string            `json:"method"`
+  URL     string            `json:"url"`
+  Headers map[string]string `json:"headers,omitempty"`
+  Body    string            `json:"body"`
+}
+
+// Response - Recorded response. Body is the scrubbed body.
+type Response struct {
+  StatusCode int               `json:"status_code"`
+  Headers    map[string]string `json:"headers,omitempty"`
+  Body       string            `json:"body"`
+}
+
+// Interaction - A request and the response it got.
+type Interaction struct {
+  Request  Request  `json:"request"`
+  Response Response `json:"response"`
+}
+
+// Cassette - Ordered interactions, stored as an indented JSON file.
+type Cassette struct {
+  Interactions []Interaction `json:"interactions"`
+}
+
+// secretKeys - Header and JSON keys whose values are replaced by logging.Redacted.
+var secretKeys = map[string]bool{
+  "authorization": true,
+  "cookie":        true,
+  "set-cookie":    true,
+  "token":         true,
+  "jwt":           true,
+  "secret":        true,
+  "secret_key":    true,
+  "api_key":       true,
+  "password":      true,
+}
+
+// Load - Reads a cassette file.
+func Load(path string) (*Cassette, error) {
+  blob, err := ioutil.ReadFile(path)
+
+  if err != nil {
+    return nil, err
+  }
+
+  cassette := &Cassette{}
+
+  if err := json.Unmarshal(blob, cassette); err != nil {
+    return nil, err
+  }
+
+  return cassette, nil
+}
+
+// Save - Writes the cassette file, replacing it atomically.
+func (cassette *Cassette) Save(path string) error {
+  blob, err := json.MarshalIndent(cassette, "", "  ")
+
+  if err != nil {
+    return err
+  }
+
+  temp := path + ".tmp"
+
+  if err := ioutil.WriteFile(temp, append(blob, '\n'), 0600); err != nil {
+    return err
+  }
+
+  if err := os.Rename(temp, path); err != nil {
+    os.Remove(temp)
+    return err
+  }
+
+  return nil
+}
+
+// ScrubHeaders - Redacts the credential headers.
+func ScrubHeaders(headers map[string]string) map[string]string {
+  scrubbed := map[string]string{}
+
+  for header, value := range headers {
+    if secretKeys[strings.ToLower(header)] {
+      value = logging.Redacted
+    }
+
+    scrubbed[header] = value
+  }
+
+  return scrubbed
+}
+
+// ScrubBody - Scrubs a body. JSON bodies are normalized (keys sorted, no spaces) and scrubbed field by field:
+// credentials are redacted, "curp" and "email" masked, every string of "full_data" hashed and the rest
+// of the strings scrubbed as free text. Other bodies are scrubbed as free text.
+// The result is deterministic, so scrubbed bodies of equal requests are equal.
+func ScrubBody(body string) string {
+  var document interface{}
+
+  if err := json.Unmarshal([]byte(body), &document); err != nil {
+    return logging.Scrub(body)
+  }
+
+  blob, _ := json.Marshal(scrubValue("", document, false))
+  return string(blob)
+}
+
+func scrubValue(key string, value interface{}, fullData bool) interface{} {
+  switch typed := value.(type) {
+  case map[string]interface{}:
+    scrubbed := map[string]interface{}{}
+
+    for child, childValue := range typed {
+      scrubbed[child] = scrubValue(strings.ToLower(child), childValue, fullData || strings.ToLower(child) == "full_data")
+    }
+
+    return scrubbed
+  case []interface{}:
+    scrubbed := []interface{}{}
+
+    for _, item := range typed {
+      scrubbed = append(scrubbed, scrubValue(key, item, fullData))
+    }
+
+    return scrubbed
+  case string:
+    return scrubString(key, typed, fullData)
+  }
+
+  return value
+}
+
+func scrubString(key, value string, fullData bool) string {
+  switch {
+  case secretKeys[key]:
+    return logging.Redacted
+  case key == "curp":
+    return logging.MaskCurp(value)
+  case key == "email":
+    return logging.MaskEmail(value)
+  case fullData:
+    return logging.Hash(value, "")
+  }
+
+  return logging.Scrub(value)
+}
+
+// restorations - Pairs of scrubbed and original strings of a JSON body, used to put the PII
+// of a replayed request back into the recorded response (e.g. the CURP echoed by lead-evaluation).
+func restorations(body string) map[string]string {
+  var document interface{}
+  pairs := map[string]string{}
+
+  if err := json.Unmarshal([]byte(body), &document); err != nil {
+    return pairs
+  }
+
+  collect("", document, false, pairs)
+  return pairs
+}
+
+func collect(key string, value interface{}, fullData bool, pairs map[string]string) {
+  switch typed := value.(type) {
+  case map[string]interface{}:
+    for child, childValue := range typed {
+      collect(strings.ToLower(child), childValue, fullData || strings.ToLower(child) == "full_data", pairs)
+    }
+  case []interface{}:
+    for _, item := range typed {
+      collect(key, item, fullData, pairs)
+    }
+  case string:
+    if scrubbed := scrubString(key, typed, fullData); scrubbed != typed && scrubbed != logging.Redacted {
+      pairs[scrubbed] = typed
+    }
+  }
+}
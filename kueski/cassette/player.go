@@ -0,0 +1,193 @@
+package cassette
+
+import (
+  "fmt"
+  "io/ioutil"
+  "net/http"
+  "net/url"
+  "strings"
+  "sync"
+
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski/logging"
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski/util"
+)
+
+// Reporter - Receives the unmatched requests, e.g. a *testing.T.
+type Reporter interface {
+  Errorf(format string, args ...interface{})
+}
+
+// UnmatchedRequestError - No recorded interaction matches the request.
+// Query - The scrubbed query, with its parameters sorted.
+type UnmatchedRequestError struct {
+  Method string
+  Path   string
+  Query  string
+  Body   string
+}
+
+func (err UnmatchedRequestError) Error() string {
+  target := err.Path
+
+  if err.Query != "" {
+    target += "?" + err.Query
+  }
+
+  return fmt.Sprintf("cassette: no interaction matches %s %s with body %s", err.Method, target, err.Body)
+}
+
+// Player - RoundTripper answering with the recorded responses, without network access.
+// Requests match an interaction by method, URL path, scrubbed query, whatever the order of its parameters,
+// and scrubbed body. Interactions are played in recorded order; once all the matching ones were played,
+// the last one is repeated.
+type Player struct {
+  cassette  *Cassette
+  played    []bool
+  reporter  Reporter
+  unmatched []UnmatchedRequestError
+  sync.Mutex
+}
+
+// NewPlayer - Loads the cassette file to replay.
+// reporter - Notified of every unmatched request, so tests fail loudly. Optional.
+func NewPlayer(path string, reporter Reporter) (*Player, error) {
+  cassette, err := Load(path)
+
+  if err != nil {
+    return nil, err
+  }
+
+  return &Player{cassette: cassette, played: make([]bool, len(cassette.Interactions)), reporter: reporter}, nil
+}
+
+// Middleware - Replaces the wrapped transport with the player.
+func (player *Player) Middleware() util.Middleware {
+  return func(next http.RoundTripper) http.RoundTripper {
+    return player
+  }
+}
+
+// RoundTrip - Answers with the matching recorded response, or fails with UnmatchedRequestError.
+func (player *Player) RoundTrip(request *http.Request) (*http.Response, error) {
+  body, err := readBody(request)
+
+  if err != nil {
+    return nil, err
+  }
+
+  scrubbed := ScrubBody(string(body))
+  interaction, err := player.match(request.Method, request.URL.Path, scrubQuery(request.URL), scrubbed)
+
+  if err != nil {
+    return nil, err
+  }
+
+  responseBody := interaction.Response.Body
+
+  for scrubbedValue, original := range restorations(string(body)) {
+    responseBody = strings.Replace(responseBody, scrubbedValue, original, -1)
+  }
+
+  response := &http.Response{
+    Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+    StatusCode:    interaction.Response.StatusCode,
+    Proto:         "HTTP/1.1",
+    ProtoMajor:    1,
+    ProtoMinor:    1,
+    Header:        http.Header{},
+    Body:          ioutil.NopCloser(strings.NewReader(responseBody)),
+    ContentLength: int64(len(responseBody)),
+    Request:       request,
+  }
+
+  for header, value := range interaction.Response.Headers {
+    response.Header.Set(header, value)
+  }
+
+  response.Header.Del("Content-Length")
+  return response, nil
+}
+
+// Unplayed - Recorded interactions no request matched yet.
+func (player *Player) Unplayed() []Interaction {
+  player.Lock()
+  defer player.Unlock()
+
+  unplayed := []Interaction{}
+
+  for i, interaction := range player.cassette.Interactions {
+    if !player.played[i] {
+      unplayed = append(unplayed, interaction)
+    }
+  }
+
+  return unplayed
+}
+
+// Unmatched - Requests no interaction matched.
+func (player *Player) Unmatched() []UnmatchedRequestError {
+  player.Lock()
+  defer player.Unlock()
+  return append([]UnmatchedRequestError{}, player.unmatched...)
+}
+
+func (player *Player) match(method, path, query, body string) (Interaction, error) {
+  player.Lock()
+  defer player.Unlock()
+
+  last := -1
+
+  for i, interaction := range player.cassette.Interactions {
+    if !matches(interaction.Request, method, path, query, body) {
+      continue
+    }
+
+    if !player.played[i] {
+      player.played[i] = true
+      return interaction, nil
+    }
+
+    last = i
+  }
+
+  if last >= 0 {
+    return player.cassette.Interactions[last], nil
+  }
+
+  err := UnmatchedRequestError{method, path, query, body}
+  player.unmatched = append(player.unmatched, err)
+
+  if player.reporter != nil {
+    player.reporter.Errorf("%v", err)
+  }
+
+  return Interaction{}, err
+}
+
+func matches(recorded Request, method, path, query, body string) bool {
+  recordedURL, err := url.Parse(recorded.URL)
+
+  if err != nil || recorded.Method != method || recordedURL.Path != path || recorded.Body != body {
+    return false
+  }
+
+  recordedQuery, err := url.ParseQuery(recordedURL.RawQuery)
+  return err == nil && recordedQuery.Encode() == query
+}
+
+// scrubQuery - The query of the URL scrubbed as the recorder does, parameters sorted by url.Values.
+func scrubQuery(requestURL *url.URL) string {
+  scrubbed, err := url.Parse(logging.Scrub(requestURL.String()))
+
+  if err != nil {
+    return requestURL.RawQuery
+  }
+
+  query, err := url.ParseQuery(scrubbed.RawQuery)
+
+  if err != nil {
+    return scrubbed.RawQuery
+  }
+
+  return query.Encode()
+}
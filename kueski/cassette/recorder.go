@@ -0,0 +1,126 @@
+package cassette
+
+import (
+  "bytes"
+  "io/ioutil"
+  "net/http"
+  "strings"
+  "sync"
+
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski/logging"
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski/util"
+)
+
+// Recorder - RoundTripper sending the requests through next and saving every interaction, scrubbed,
+// to the cassette file.
+type Recorder struct {
+  path     string
+  next     http.RoundTripper
+  cassette Cassette
+  sync.Mutex
+}
+
+// NewRecorder - Recorder constructor. The cassette file is overwritten on the first interaction.
+// next - Transport the requests are sent through, http.DefaultTransport when nil.
+func NewRecorder(path string, next http.RoundTripper) *Recorder {
+  if next == nil {
+    next = http.DefaultTransport
+  }
+
+  return &Recorder{path: path, next: next}
+}
+
+// Middleware - Records the interactions of the wrapped transport instead of the one given to NewRecorder.
+func (recorder *Recorder) Middleware() util.Middleware {
+  return func(next http.RoundTripper) http.RoundTripper {
+    return util.RoundTripperFunc(func(request *http.Request) (*http.Response, error) {
+      return recorder.record(next, request)
+    })
+  }
+}
+
+// RoundTrip - Sends the request and records the interaction. Failed round trips are not recorded.
+func (recorder *Recorder) RoundTrip(request *http.Request) (*http.Response, error) {
+  return recorder.record(recorder.next, request)
+}
+
+// Cassette - Interactions recorded so far.
+func (recorder *Recorder) Cassette() Cassette {
+  recorder.Lock()
+  defer recorder.Unlock()
+  return Cassette{append([]Interaction{}, recorder.cassette.Interactions...)}
+}
+
+func (recorder *Recorder) record(next http.RoundTripper, request *http.Request) (*http.Response, error) {
+  requestBody, err := readBody(request)
+
+  if err != nil {
+    return nil, err
+  }
+
+  response, err := next.RoundTrip(request)
+
+  if err != nil {
+    return response, err
+  }
+
+  responseBody, err := ioutil.ReadAll(response.Body)
+  response.Body.Close()
+  response.Body = ioutil.NopCloser(bytes.NewReader(responseBody))
+
+  if err != nil {
+    return nil, err
+  }
+
+  interaction := Interaction{
+    Request: Request{
+      Method:  request.Method,
+      URL:     logging.Scrub(request.URL.String()),
+      Headers: ScrubHeaders(flatten(request.Header)),
+      Body:    ScrubBody(string(requestBody)),
+    },
+    Response: Response{
+      StatusCode: response.StatusCode,
+      Headers:    ScrubHeaders(flatten(response.Header)),
+      Body:       ScrubBody(string(responseBody)),
+    },
+  }
+
+  recorder.Lock()
+  defer recorder.Unlock()
+
+  recorder.cassette.Interactions = append(recorder.cassette.Interactions, interaction)
+
+  if err := recorder.cassette.Save(recorder.path); err != nil {
+    return nil, err
+  }
+
+  return response, nil
+}
+
+// readBody - Reads the request body, leaving it in place to be sent.
+func readBody(request *http.Request) ([]byte, error) {
+  if request.Body == nil {
+    return nil, nil
+  }
+
+  body, err := ioutil.ReadAll(request.Body)
+  request.Body.Close()
+
+  if err != nil {
+    return nil, err
+  }
+
+  request.Body = ioutil.NopCloser(bytes.NewReader(body))
+  return body, nil
+}
+
+func flatten(header http.Header) map[string]string {
+  flat := map[string]string{}
+
+  for key, values := range header {
+    flat[key] = strings.Join(values, ", ")
+  }
+
+  return flat
+}
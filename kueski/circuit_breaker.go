@@ -0,0 +1,78 @@
+package kueski
+
+import (
+  "context"
+
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski/breaker"
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski/util"
+)
+
+// SetCircuitBreaker - Protects the client with a circuit breaker. It trips after
+// settings.FailureThreshold consecutive connection errors, timeouts or 5xx responses, requests canceled
+// through their context aside, making every call fail fast with CircuitOpen until a half-open trial
+// request succeeds. Each failover host gets its own breaker with the same settings.
+// Transitions are reported to settings.OnStateChange and to the CircuitStateChanged hooks, along with the host.
+func (client *Client) SetCircuitBreaker(settings breaker.Settings) {
+  client.breakerConf = &settings
+  client.breakers = map[string]*breaker.Breaker{client.url: client.newBreaker(client.url)}
+
+  if client.hosts == nil {
+    return
+  }
+
+  client.hosts.Lock()
+  defer client.hosts.Unlock()
+
+  for _, host := range client.hosts.hosts {
+    if client.breakers[host.url] == nil {
+      client.breakers[host.url] = client.newBreaker(host.url)
+    }
+  }
+}
+
+// newBreaker - Circuit breaker of a host, nil when SetCircuitBreaker was not called.
+func (client *Client) newBreaker(host string) *breaker.Breaker {
+  if client.breakerConf == nil {
+    return nil
+  }
+
+  settings := *client.breakerConf
+  onStateChange := settings.OnStateChange
+  settings.OnStateChange = func(from, to breaker.State) {
+    client.log().Warn("kueski circuit breaker state changed", "host", host, "from", from.String(), "to", to.String())
+    client.circuitStateChanged(host, from, to)
+
+    if onStateChange != nil {
+      onStateChange(from, to)
+    }
+  }
+
+  return breaker.New(settings)
+}
+
+// CircuitState - Current state of the circuit breaker of the primary host, Closed when there is none.
+// See HostHealth for the failover hosts.
+func (client *Client) CircuitState() breaker.State {
+  return client.circuitState(client.url)
+}
+
+// circuitState - Current state of the circuit breaker of a host, Closed when there is none.
+func (client *Client) circuitState(host string) breaker.State {
+  if circuit := client.breakers[host]; circuit != nil {
+    return circuit.State()
+  }
+
+  return breaker.Closed
+}
+
+// circuitFailure - Whether the outcome of a request means the API is not available:
+// connection errors, timeouts included, and 5xx responses.
+func circuitFailure(response *util.Response, err error) bool {
+  return err != nil || response.StatusCode >= 500
+}
+
+// canceled - The caller canceled the request, which tells nothing about the API. The requester maps
+// the error of a canceled round trip to a connection error, so the context is checked as well.
+func canceled(ctx context.Context, err error) bool {
+  return err == context.Canceled || (err != nil && ctx.Err() == context.Canceled)
+}
@@ -0,0 +1,105 @@
+package kueski
+
+import (
+  "context"
+  "net/http"
+  "net/http/httptest"
+  "testing"
+  "time"
+
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski/breaker"
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski/errors"
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski/util"
+  "github.com/stretchr/testify/assert"
+)
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestCircuitBreaker(t *testing.T) {
+  calls := 0
+  available := false
+  transitions := []breaker.State{}
+
+  client := NewClient("http://kueski.com", "Key", "Secret")
+  client.jwtProvider = &fakeTokenProvider{true}
+  client.requester = func(url string, headers map[string]string, body []byte) (*http.Response, error) {
+    calls++
+
+    if available {
+      return buildHTTPResponse(400, `{ "error": "curp is invalid" }`), nil
+    }
+
+    return nil, errors.UnableToMakeConnection
+  }
+  client.AddHooks(Hooks{CircuitStateChanged: func(host string, from, to breaker.State) {
+    assert.Equal(t, "http://kueski.com", host)
+    transitions = append(transitions, to)
+  }})
+  collector := client.EnableMetrics()
+  client.SetCircuitBreaker(breaker.Settings{FailureThreshold: 2, OpenTimeout: 20 * time.Millisecond})
+
+  assert.Equal(t, breaker.Closed, client.CircuitState())
+
+  for i := 0; i < 3; i++ {
+    _, err := leadEvaluation(context.Background(), client, curp, email)
+    assert.NotNil(t, err)
+  }
+
+  _, err := client.RequestToken()
+  assert.Equal(t, errors.CircuitOpen, err)
+  assert.Equal(t, 2, calls)
+  assert.Equal(t, breaker.Open, client.CircuitState())
+  assert.Equal(t, 2.0, collector.circuitStates.Value("http://kueski.com"))
+
+  time.Sleep(20 * time.Millisecond)
+  available = true
+  _, err = leadEvaluation(context.Background(), client, curp, email)
+
+  assert.Equal(t, errors.InvalidCurp, err)
+  assert.Equal(t, breaker.Closed, client.CircuitState())
+  assert.Equal(t, []breaker.State{breaker.Open, breaker.HalfOpen, breaker.Closed}, transitions)
+  assert.Equal(t, 1.0, collector.transitions.Value("http://kueski.com", "half-open"))
+}
+
+func TestCircuitFailure(t *testing.T) {
+  assert.True(t, circuitFailure(nil, errors.UnableToMakeConnection))
+  assert.True(t, circuitFailure(nil, timeoutError{}))
+  assert.True(t, circuitFailure(util.WrapResponse(buildHTTPResponse(503, "")), nil))
+  assert.False(t, circuitFailure(util.WrapResponse(buildHTTPResponse(401, "")), nil))
+  assert.Equal(t, breaker.Closed, (&Client{}).CircuitState())
+
+  ctx, cancel := context.WithCancel(context.Background())
+  assert.False(t, canceled(ctx, errors.UnableToMakeConnection))
+  assert.True(t, canceled(ctx, context.Canceled))
+  cancel()
+  assert.True(t, canceled(ctx, errors.UnableToMakeConnection))
+  assert.False(t, canceled(ctx, nil))
+}
+
+func TestCircuitBreakerIgnoresCancellations(t *testing.T) {
+  ctx, cancel := context.WithCancel(context.Background())
+  requests := 0
+  ts := httptest.NewServer(http.HandlerFunc(func(responseWriter http.ResponseWriter, request *http.Request) {
+    requests++
+    cancel()
+    <-request.Context().Done()
+  }))
+  defer ts.Close()
+
+  client := NewClient(ts.URL, "Key", "Secret")
+  client.jwtProvider = &fakeTokenProvider{true}
+  client.SetCircuitBreaker(breaker.Settings{FailureThreshold: 1, OpenTimeout: time.Minute})
+
+  _, err := client.makeRequest(ctx, "path", []byte(""))
+  assert.Equal(t, errors.UnableToMakeConnection, err)
+
+  _, err = client.makeRequest(ctx, "path", []byte(""))
+  assert.Equal(t, context.Canceled, err)
+
+  assert.Equal(t, 1, requests)
+  assert.Equal(t, breaker.Closed, client.CircuitState())
+}
@@ -0,0 +1,267 @@
+// Package commission tracks the conversions of the leads sent to Kueski and computes the affiliate
+// commissions they earn, from configurable payout rules.
+package commission
+
+import (
+  goerrors "errors"
+  "sort"
+  "sync"
+  "time"
+
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski"
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski/errors"
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski/webhook"
+)
+
+// Duplicated - Evaluate outcome of a lead already sent by another affiliate.
+// Existing   - Evaluate outcome of a lead that already is a Kueski customer.
+const (
+  Duplicated kueski.Status = "duplicated"
+  Existing   kueski.Status = "existing"
+)
+
+// eventStatuses - Conversion status of each webhook event.
+var eventStatuses = map[webhook.EventType]kueski.Status{
+  webhook.EventEvaluated: kueski.StatusInProgress,
+  webhook.EventApproved:  kueski.StatusApproved,
+  webhook.EventFunded:    kueski.StatusFunded,
+  webhook.EventRejected:  kueski.StatusRejected,
+}
+
+// Change - A status the lead reached, and when.
+type Change struct {
+  Status kueski.Status `json:"status"`
+  At     time.Time     `json:"at"`
+}
+
+// Conversion - A lead sent to Kueski and the statuses it went through.
+type Conversion struct {
+  RequestID string        `json:"request_id"`
+  Campaign  string        `json:"campaign"`
+  Status    kueski.Status `json:"status"`
+  History   []Change      `json:"history"`
+}
+
+// Tier - Amount paid per lead once Count leads reach the status in the period.
+type Tier struct {
+  Count  int
+  Amount int64
+}
+
+// Rule - Payout of the leads of a campaign reaching a status. Amounts are in cents.
+// Campaign - Empty for every campaign without a rule of its own.
+// Amount - Paid per lead.
+// Tiers - Volume tiers: the highest one reached in the period replaces Amount for every lead of the period.
+type Rule struct {
+  Campaign string
+  Status   kueski.Status
+  Amount   int64
+  Tiers    []Tier
+}
+
+// Line - Commission of the leads of a campaign that reached a status in the period.
+type Line struct {
+  Campaign   string
+  Status     kueski.Status
+  Count      int
+  Rate       int64
+  Amount     int64
+  RequestIDs []string
+}
+
+// Statement - Commissions earned in [From, To). Amounts are in cents.
+type Statement struct {
+  From  time.Time
+  To    time.Time
+  Lines []Line
+  Total int64
+}
+
+// Tracker - Records the conversions and computes the commission statements.
+type Tracker struct {
+  store Store
+  rules []Rule
+  now   func() time.Time
+  sync.Mutex
+}
+
+// NewTracker - Tracker constructor.
+// store - Conversions persistence, a MemoryStore when nil.
+// rules - Payout rules. Statuses without a rule are not paid.
+func NewTracker(store Store, rules []Rule) *Tracker {
+  if store == nil {
+    store = NewMemoryStore()
+  }
+
+  return &Tracker{store: store, rules: rules, now: time.Now}
+}
+
+// RecordEvaluation - Records the outcome of Evaluate. Outcomes without a request ID, or failures
+// other than DuplicatedLead and ExistingLead, wrapped or not, are ignored.
+func (tracker *Tracker) RecordEvaluation(requestID, campaign string, err error) error {
+  if requestID == "" {
+    return nil
+  }
+
+  var status kueski.Status
+
+  switch {
+  case err == nil:
+    status = kueski.StatusInProgress
+  case goerrors.Is(err, errors.DuplicatedLead):
+    status = Duplicated
+  case goerrors.Is(err, errors.ExistingLead):
+    status = Existing
+  default:
+    return nil
+  }
+
+  tracker.Lock()
+  defer tracker.Unlock()
+
+  conversion, _, storeErr := tracker.store.Get(requestID)
+
+  if storeErr != nil {
+    return storeErr
+  }
+
+  conversion.RequestID = requestID
+  conversion.Campaign = campaign
+  return tracker.change(conversion, status, tracker.now())
+}
+
+// RecordStatus - Records a status change, e.g. from LeadStatus. Repeated statuses are ignored,
+// so it is safe to record every poll or webhook retry. Unknown request IDs are recorded without campaign.
+func (tracker *Tracker) RecordStatus(requestID string, status kueski.Status, at time.Time) error {
+  tracker.Lock()
+  defer tracker.Unlock()
+
+  conversion, _, err := tracker.store.Get(requestID)
+
+  if err != nil {
+    return err
+  }
+
+  conversion.RequestID = requestID
+  return tracker.change(conversion, status, at)
+}
+
+// RecordEvent - Records the status change of a webhook event.
+func (tracker *Tracker) RecordEvent(event webhook.Event) error {
+  status, ok := eventStatuses[event.Type]
+
+  if !ok {
+    return nil
+  }
+
+  return tracker.RecordStatus(event.RequestID, status, event.OccurredAt)
+}
+
+// Conversion - Recorded conversion of the request ID.
+func (tracker *Tracker) Conversion(requestID string) (Conversion, bool, error) {
+  return tracker.store.Get(requestID)
+}
+
+// Statement - Commissions of the statuses reached in [from, to), one line per campaign and status.
+func (tracker *Tracker) Statement(from, to time.Time) (Statement, error) {
+  conversions, err := tracker.store.List()
+
+  if err != nil {
+    return Statement{}, err
+  }
+
+  statement := Statement{From: from, To: to}
+  lines := map[[2]string]*Line{}
+
+  for _, conversion := range conversions {
+    for _, change := range conversion.History {
+      if change.At.Before(from) || !change.At.Before(to) {
+        continue
+      }
+
+      key := [2]string{conversion.Campaign, string(change.Status)}
+
+      if lines[key] == nil {
+        lines[key] = &Line{Campaign: conversion.Campaign, Status: change.Status}
+      }
+
+      lines[key].Count++
+      lines[key].RequestIDs = append(lines[key].RequestIDs, conversion.RequestID)
+    }
+  }
+
+  for _, line := range lines {
+    rule, ok := tracker.rule(line.Campaign, line.Status)
+
+    if !ok {
+      continue
+    }
+
+    line.Rate = rule.rate(line.Count)
+    line.Amount = line.Rate * int64(line.Count)
+    statement.Lines = append(statement.Lines, *line)
+    statement.Total += line.Amount
+  }
+
+  sort.Slice(statement.Lines, func(i, j int) bool {
+    if statement.Lines[i].Campaign != statement.Lines[j].Campaign {
+      return statement.Lines[i].Campaign < statement.Lines[j].Campaign
+    }
+
+    return statement.Lines[i].Status < statement.Lines[j].Status
+  })
+
+  return statement, nil
+}
+
+// change - Appends the status to the history unless the lead already reached it. Must hold the lock.
+func (tracker *Tracker) change(conversion Conversion, status kueski.Status, at time.Time) error {
+  for _, previous := range conversion.History {
+    if previous.Status == status {
+      return tracker.store.Put(conversion)
+    }
+  }
+
+  conversion.Status = status
+  conversion.History = append(conversion.History, Change{status, at})
+  return tracker.store.Put(conversion)
+}
+
+// rule - The rule of the campaign and status, falling back to the one for every campaign.
+func (tracker *Tracker) rule(campaign string, status kueski.Status) (Rule, bool) {
+  var fallback *Rule
+
+  for i, rule := range tracker.rules {
+    if rule.Status != status {
+      continue
+    }
+
+    if rule.Campaign == campaign {
+      return rule, true
+    }
+
+    if rule.Campaign == "" && fallback == nil {
+      fallback = &tracker.rules[i]
+    }
+  }
+
+  if fallback == nil {
+    return Rule{}, false
+  }
+
+  return *fallback, true
+}
+
+func (rule Rule) rate(count int) int64 {
+  rate := rule.Amount
+  reached := 0
+
+  for _, tier := range rule.Tiers {
+    if count >= tier.Count && tier.Count >= reached {
+      rate = tier.Amount
+      reached = tier.Count
+    }
+  }
+
+  return rate
+}
@@ -0,0 +1,105 @@
+package commission
+
+import (
+  "fmt"
+  "io/ioutil"
+  "os"
+  "path/filepath"
+  "testing"
+  "time"
+
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski"
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski/errors"
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski/util"
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski/webhook"
+  "github.com/stretchr/testify/assert"
+)
+
+var march = time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+func TestStatement(t *testing.T) {
+  tracker := NewTracker(nil, []Rule{
+    {Status: kueski.StatusApproved, Amount: 5000},
+    {Status: kueski.StatusFunded, Amount: 20000, Tiers: []Tier{{Count: 2, Amount: 25000}, {Count: 10, Amount: 30000}}},
+    {Campaign: "summer", Status: kueski.StatusApproved, Amount: 8000},
+  })
+  tracker.now = func() time.Time { return march }
+
+  assert.Nil(t, tracker.RecordEvaluation("1", "summer", nil))
+  assert.Nil(t, tracker.RecordEvaluation("2", "", nil))
+  assert.Nil(t, tracker.RecordEvaluation("3", "", nil))
+  assert.Nil(t, tracker.RecordEvaluation("4", "", errors.DuplicatedLead))
+  assert.Nil(t, tracker.RecordEvaluation("", "", errors.InvalidCurp))
+
+  day := 24 * time.Hour
+  assert.Nil(t, tracker.RecordStatus("1", kueski.StatusApproved, march.Add(day)))
+  assert.Nil(t, tracker.RecordStatus("1", kueski.StatusApproved, march.Add(2*day)))
+  assert.Nil(t, tracker.RecordStatus("2", kueski.StatusApproved, march.Add(day)))
+  assert.Nil(t, tracker.RecordEvent(webhook.Event{Type: webhook.EventFunded, RequestID: "2", OccurredAt: march.Add(3 * day)}))
+  assert.Nil(t, tracker.RecordStatus("3", kueski.StatusFunded, march.Add(4*day)))
+  assert.Nil(t, tracker.RecordStatus("3", kueski.StatusRejected, march.Add(40*day)))
+
+  statement, err := tracker.Statement(march, march.AddDate(0, 1, 0))
+
+  assert.Nil(t, err)
+  assert.Equal(t, []Line{
+    {Campaign: "", Status: kueski.StatusApproved, Count: 1, Rate: 5000, Amount: 5000, RequestIDs: []string{"2"}},
+    {Campaign: "", Status: kueski.StatusFunded, Count: 2, Rate: 25000, Amount: 50000, RequestIDs: []string{"2", "3"}},
+    {Campaign: "summer", Status: kueski.StatusApproved, Count: 1, Rate: 8000, Amount: 8000, RequestIDs: []string{"1"}},
+  }, statement.Lines)
+  assert.Equal(t, int64(63000), statement.Total)
+
+  conversion, ok, _ := tracker.Conversion("1")
+  assert.True(t, ok)
+  assert.Equal(t, kueski.StatusApproved, conversion.Status)
+  assert.Equal(t, 2, len(conversion.History))
+
+  conversion, _, _ = tracker.Conversion("4")
+  assert.Equal(t, Duplicated, conversion.Status)
+
+  _, ok, _ = tracker.Conversion("5")
+  assert.False(t, ok)
+}
+
+func TestRecordEvaluationErrors(t *testing.T) {
+  store := NewMemoryStore()
+  tracker := NewTracker(store, nil)
+  invalid := util.FullDataFieldsError{Err: (&kueski.LeadProfile{}).Validate()}
+
+  assert.NotPanics(t, func() { assert.Nil(t, tracker.RecordEvaluation("1", "", invalid)) })
+  assert.Nil(t, tracker.RecordEvaluation("", "", invalid))
+  assert.Nil(t, tracker.RecordEvaluation("2", "", fmt.Errorf("lead evaluation: %w", errors.ExistingLead)))
+
+  _, ok, _ := store.Get("1")
+  assert.False(t, ok)
+  existing, ok, _ := store.Get("2")
+  assert.True(t, ok)
+  assert.Equal(t, Existing, existing.Status)
+}
+
+func TestFileStore(t *testing.T) {
+  dir, _ := ioutil.TempDir("", "commission")
+  defer os.RemoveAll(dir)
+  path := filepath.Join(dir, "conversions.json")
+
+  store, err := NewFileStore(path)
+  assert.Nil(t, err)
+
+  conversion := Conversion{"1", "summer", kueski.StatusFunded, []Change{{kueski.StatusFunded, march}}}
+  assert.Nil(t, store.Put(conversion))
+
+  store, err = NewFileStore(path)
+  assert.Nil(t, err)
+
+  loaded, ok, err := store.Get("1")
+  assert.Nil(t, err)
+  assert.True(t, ok)
+  assert.Equal(t, conversion, loaded)
+
+  list, _ := store.List()
+  assert.Equal(t, []Conversion{conversion}, list)
+
+  ioutil.WriteFile(path, []byte("not json"), 0600)
+  _, err = NewFileStore(path)
+  assert.NotNil(t, err)
+}
@@ -0,0 +1,158 @@
+package commission
+
+import (
+  "encoding/json"
+  "io/ioutil"
+  "os"
+  "path/filepath"
+  "sort"
+  "sync"
+)
+
+// Store - Persistence of the conversions, keyed by request ID.
+type Store interface {
+  Get(requestID string) (Conversion, bool, error)
+  Put(conversion Conversion) error
+  List() ([]Conversion, error)
+}
+
+// MemoryStore - In memory Store, conversions are lost on restart.
+type MemoryStore struct {
+  conversions map[string]Conversion
+  sync.Mutex
+}
+
+// NewMemoryStore - MemoryStore constructor.
+func NewMemoryStore() *MemoryStore {
+  return &MemoryStore{conversions: map[string]Conversion{}}
+}
+
+// Get - Conversion of the request ID, if recorded.
+func (store *MemoryStore) Get(requestID string) (Conversion, bool, error) {
+  store.Lock()
+  defer store.Unlock()
+
+  conversion, ok := store.conversions[requestID]
+  return conversion, ok, nil
+}
+
+// Put - Saves the conversion, replacing the previous one of its request ID.
+func (store *MemoryStore) Put(conversion Conversion) error {
+  store.Lock()
+  defer store.Unlock()
+
+  store.conversions[conversion.RequestID] = conversion
+  return nil
+}
+
+// List - Every conversion, sorted by request ID.
+func (store *MemoryStore) List() ([]Conversion, error) {
+  store.Lock()
+  defer store.Unlock()
+  return sorted(store.conversions), nil
+}
+
+// FileStore - Store persisted as a JSON file, rewritten atomically on every change.
+type FileStore struct {
+  path        string
+  conversions map[string]Conversion
+  sync.Mutex
+}
+
+// NewFileStore - FileStore constructor, loading the conversions from path when the file exists.
+func NewFileStore(path string) (*FileStore, error) {
+  store := &FileStore{path: path, conversions: map[string]Conversion{}}
+  blob, err := ioutil.ReadFile(path)
+
+  if os.IsNotExist(err) {
+    return store, nil
+  }
+
+  if err != nil {
+    return nil, err
+  }
+
+  if len(blob) > 0 {
+    if err := json.Unmarshal(blob, &store.conversions); err != nil {
+      return nil, err
+    }
+  }
+
+  return store, nil
+}
+
+// Get - Conversion of the request ID, if recorded.
+func (store *FileStore) Get(requestID string) (Conversion, bool, error) {
+  store.Lock()
+  defer store.Unlock()
+
+  conversion, ok := store.conversions[requestID]
+  return conversion, ok, nil
+}
+
+// Put - Saves the conversion and the file.
+func (store *FileStore) Put(conversion Conversion) error {
+  store.Lock()
+  defer store.Unlock()
+
+  previous, existed := store.conversions[conversion.RequestID]
+  store.conversions[conversion.RequestID] = conversion
+
+  if err := store.save(); err != nil {
+    if existed {
+      store.conversions[conversion.RequestID] = previous
+    } else {
+      delete(store.conversions, conversion.RequestID)
+    }
+
+    return err
+  }
+
+  return nil
+}
+
+// List - Every conversion, sorted by request ID.
+func (store *FileStore) List() ([]Conversion, error) {
+  store.Lock()
+  defer store.Unlock()
+  return sorted(store.conversions), nil
+}
+
+func (store *FileStore) save() error {
+  blob, err := json.MarshalIndent(store.conversions, "", "  ")
+
+  if err != nil {
+    return err
+  }
+
+  temp, err := ioutil.TempFile(filepath.Dir(store.path), filepath.Base(store.path)+".tmp")
+
+  if err != nil {
+    return err
+  }
+
+  _, err = temp.Write(blob)
+  closeErr := temp.Close()
+
+  if err == nil {
+    err = closeErr
+  }
+
+  if err != nil {
+    os.Remove(temp.Name())
+    return err
+  }
+
+  return os.Rename(temp.Name(), store.path)
+}
+
+func sorted(conversions map[string]Conversion) []Conversion {
+  list := []Conversion{}
+
+  for _, conversion := range conversions {
+    list = append(list, conversion)
+  }
+
+  sort.Slice(list, func(i, j int) bool { return list[i].RequestID < list[j].RequestID })
+  return list
+}
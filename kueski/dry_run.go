@@ -0,0 +1,88 @@
+package kueski
+
+import (
+  "encoding/json"
+  "fmt"
+  "sort"
+  "strings"
+  "time"
+
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski/errors"
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski/logging"
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski/util"
+)
+
+// DryRunRequestID - Placeholder of the request ID in the dry run lead data body,
+// since the real one is only known after the lead evaluation.
+const DryRunRequestID string = "DRY-RUN"
+
+// DryRunRequest - A request Evaluate would send. Credentials in the headers are redacted.
+type DryRunRequest struct {
+  Method  string
+  URL     string
+  Headers map[string]string
+  Body    []byte
+}
+
+// DryRun - Validates the lead and builds the requests Evaluate would send, in order, without any network call:
+// authentication, lead evaluation and lead data. Fails with the same validation errors as Evaluate.
+func (client *Client) DryRun(curp, email string, fullData interface{}) ([]DryRunRequest, error) {
+  if err := client.validate(curp, email, fullData); err != nil {
+    return nil, err
+  }
+
+  evaluationBody, err := json.Marshal(evaluation{curp, email})
+
+  if err != nil {
+    return nil, errors.LeadEvaluationMalformedRequest
+  }
+
+  dataBody, err := json.Marshal(leadFullData{fullData, DryRunRequestID})
+
+  if err != nil {
+    return nil, errors.LeadDataMalformedRequest
+  }
+
+  apiHeaders := map[string]string{
+    Authorization: fmt.Sprintf(tokenHeaderFormat, "JWT"),
+    ContentType:   ApplicationJSON,
+  }
+
+  return []DryRunRequest{
+    dryRunRequest(util.BuildURL(client.url, AuthenticatePath), client.authenticationHeaders(time.Now()), []byte(BodyString)),
+    dryRunRequest(util.BuildURL(client.url, leadEvaluationPath), apiHeaders, evaluationBody),
+    dryRunRequest(util.BuildURL(client.url, leadDataPath), apiHeaders, dataBody),
+  }, nil
+}
+
+func dryRunRequest(url string, headers map[string]string, body []byte) DryRunRequest {
+  redacted := map[string]string{}
+
+  for header, value := range headers {
+    redacted[header] = value
+
+    if header == Authorization {
+      redacted[header] = strings.SplitN(value, " ", 2)[0] + " " + logging.Redacted
+    }
+  }
+
+  return DryRunRequest{Method, url, redacted, body}
+}
+
+// String - The request in a readable HTTP like format, headers sorted.
+func (request DryRunRequest) String() string {
+  lines := []string{fmt.Sprintf("%s %s", request.Method, request.URL)}
+  headers := []string{}
+
+  for header := range request.Headers {
+    headers = append(headers, header)
+  }
+
+  sort.Strings(headers)
+
+  for _, header := range headers {
+    lines = append(lines, fmt.Sprintf("%s: %s", header, request.Headers[header]))
+  }
+
+  return strings.Join(append(lines, "", string(request.Body)), "\n")
+}
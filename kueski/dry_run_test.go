@@ -0,0 +1,43 @@
+package kueski
+
+import (
+  "encoding/json"
+  "net/http"
+  "strings"
+  "testing"
+
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski/errors"
+  "github.com/stretchr/testify/assert"
+)
+
+func TestDryRun(t *testing.T) {
+  client := NewClient("http://kueski.com", "Key", "Secret")
+  client.requester = func(url string, headers map[string]string, body []byte) (*http.Response, error) {
+    t.Fatal("dry run must not send requests")
+    return nil, nil
+  }
+
+  requests, err := client.DryRun("BADD110313HCMLNS06", "e@mail.com", map[string]string{"name": "Lead"})
+
+  assert.Nil(t, err)
+  assert.Equal(t, 3, len(requests))
+  assert.Equal(t, "http://kueski.com/"+AuthenticatePath, requests[0].URL)
+  assert.Equal(t, "APIAuth [REDACTED]", requests[0].Headers[Authorization])
+  assert.NotEmpty(t, requests[0].Headers[Date])
+  assert.NotEmpty(t, requests[0].Headers[ContentMD5])
+
+  assert.Equal(t, "http://kueski.com/"+leadEvaluationPath, requests[1].URL)
+  assert.Equal(t, "Bearer [REDACTED]", requests[1].Headers[Authorization])
+  assert.JSONEq(t, `{ "curp": "BADD110313HCMLNS06", "email": "e@mail.com" }`, string(requests[1].Body))
+
+  var data leadFullData
+  json.Unmarshal(requests[2].Body, &data)
+  assert.Equal(t, "http://kueski.com/"+leadDataPath, requests[2].URL)
+  assert.Equal(t, DryRunRequestID, data.RequestID)
+  assert.Equal(t, Method, requests[2].Method)
+
+  assert.True(t, strings.HasPrefix(requests[1].String(), "POST http://kueski.com/affiliates/lead-evaluation\nAuthorization: Bearer [REDACTED]\nContent-Type: application/json\n\n{"))
+
+  _, err = client.DryRun("BADD110313HCMLNS06", "not an email", map[string]string{})
+  assert.Equal(t, errors.InvalidEmail, err)
+}
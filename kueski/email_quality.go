@@ -0,0 +1,44 @@
+package kueski
+
+import (
+  "strings"
+
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski/errors"
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski/util"
+)
+
+// EmailQualityRule - Rule for AddRules assessing the lead email with the assessor, one with the default lists when nil.
+// Issues in errorOn fail the lead with LowQualityEmail, the others are logged as warnings.
+func (client *Client) EmailQualityRule(assessor *util.EmailAssessor, errorOn ...util.EmailIssue) Rule {
+  if assessor == nil {
+    assessor = util.NewEmailAssessor()
+  }
+
+  return func(curp, email string, fullData interface{}) error {
+    verdict := assessor.Assess(email)
+    warnings := []string{}
+
+    for _, issue := range verdict.Issues {
+      for _, failing := range errorOn {
+        if issue == failing {
+          return errors.LowQualityEmail
+        }
+      }
+
+      warnings = append(warnings, string(issue))
+    }
+
+    if len(warnings) > 0 {
+      suggested := ""
+
+      if verdict.Suggestion != "" {
+        suggested = verdict.Suggestion[strings.LastIndex(verdict.Suggestion, "@")+1:]
+      }
+
+      client.log().Warn("kueski lead email quality", "email", email, "issues", strings.Join(warnings, ","),
+        "suggested_domain", suggested)
+    }
+
+    return nil
+  }
+}
@@ -0,0 +1,32 @@
+package kueski
+
+import (
+  "strings"
+  "testing"
+
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski/errors"
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski/util"
+  "github.com/stretchr/testify/assert"
+)
+
+func TestEmailQualityRule(t *testing.T) {
+  logger := &bufferLogger{}
+  client := NewClient("http://kueski.com", "Key", "Secret")
+  client.SetLogger(logger)
+  client.AddRules(client.EmailQualityRule(nil, util.EmailDisposable))
+
+  _, err := client.DryRun("BADD110313HCMLNS06", "juan@mailinator.com", map[string]string{})
+  assert.Equal(t, errors.LowQualityEmail, err)
+  assert.Empty(t, logger.lines)
+
+  _, err = client.DryRun("BADD110313HCMLNS06", "ventas@gmial.com", map[string]string{})
+  assert.Nil(t, err)
+  assert.Len(t, logger.lines, 1)
+  assert.Contains(t, logger.lines[0], "typo,role")
+  assert.Contains(t, logger.lines[0], "gmail.com")
+  assert.False(t, strings.Contains(logger.lines[0], "ventas@"))
+
+  _, err = client.DryRun("BADD110313HCMLNS06", "juan@gmail.com", map[string]string{})
+  assert.Nil(t, err)
+  assert.Len(t, logger.lines, 1)
+}
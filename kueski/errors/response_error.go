@@ -27,6 +27,9 @@ type ResponseError int
 // InvalidLeadEvaluationResponseFormat - Error for Invalid Lead Evaluation Response Format
 // LeadDataMalformedRequest - Error for Lead Data Malformed Request
 // InvalidLeadDataResponseFormat - Error for Invalid Lead Data Response Format
+// TLSHandshakeFailed - Error for TLS Handshake Failed
+// ResponseTooLarge - Error for Response Too Large
+// InvalidLeadStatusResponseFormat - Error for Invalid Lead Status Response Format
 // AccessDenied - Error for Access Denied
 // InvalidJWTResponseFormat - Error for Invalid Jwt Response Format
 // InvalidExpirationFormat - Error for Invalid Expiration Format
@@ -35,6 +38,16 @@ type ResponseError int
 // ExpiredJWTToken - Error for Expired Jwt Token
 // ExistingLead - Error for Existing Lead
 // DuplicatedLead - Error for Duplicated Lead
+// QuotaExceeded - Error for Quota Exceeded
+// CircuitOpen - Error for Circuit Open
+// InvalidRfc - Error for Invalid Rfc
+// InvalidClabe - Error for Invalid Clabe
+// InvalidCardNumber - Error for Invalid Card Number
+// InvalidPhone - Error for Invalid Phone
+// InvalidPostalCode - Error for Invalid Postal Code
+// PostalCodeStateMismatch - Error for Postal Code State Mismatch
+// LowQualityEmail - Error for Low Quality Email
+// InvalidFullDataFields - Error for Invalid Full Data Fields
 // GeneralError - Error for General Error
 const (
   InvalidCurp                 ResponseError = 1
@@ -62,6 +75,9 @@ const (
   LeadDataMalformedRequest            ResponseError = 24
   InvalidLeadDataResponseFormat       ResponseError = 25
   ErrorNotIdentifiedFromAPI           ResponseError = 26
+  TLSHandshakeFailed                  ResponseError = 27
+  ResponseTooLarge                    ResponseError = 28
+  InvalidLeadStatusResponseFormat     ResponseError = 29
 
   AccessDenied             ResponseError = 31
   InvalidJWTResponseFormat ResponseError = 32
@@ -72,6 +88,18 @@ const (
   ExistingLead   ResponseError = 41
   DuplicatedLead ResponseError = 42
 
+  QuotaExceeded ResponseError = 51
+  CircuitOpen   ResponseError = 52
+
+  InvalidRfc              ResponseError = 61
+  InvalidClabe            ResponseError = 62
+  InvalidCardNumber       ResponseError = 63
+  InvalidPhone            ResponseError = 64
+  InvalidPostalCode       ResponseError = 65
+  PostalCodeStateMismatch ResponseError = 66
+  LowQualityEmail         ResponseError = 67
+  InvalidFullDataFields   ResponseError = 68
+
   GeneralError ResponseError = 99
 )
 
@@ -104,6 +132,9 @@ var descriptions = map[ResponseError]errorDescription{
   ErrorNotIdentifiedFromAPI:           errorDescription{"ErrorNotIdentifiedFromAPI", "Error from the API is not recognized"},
   LeadDataMalformedRequest:            errorDescription{"LeadDataMalformedRequest", "Lead Data malformed request."},
   InvalidLeadDataResponseFormat:       errorDescription{"InvalidLeadDataResponseFormat", "Invalid Lead Data response format."},
+  TLSHandshakeFailed:                  errorDescription{"TLSHandshakeFailed", "TLS handshake failed, check the root CAs, pinned keys and client certificate."},
+  ResponseTooLarge:                    errorDescription{"ResponseTooLarge", "Response body exceeds the size limit."},
+  InvalidLeadStatusResponseFormat:     errorDescription{"InvalidLeadStatusResponseFormat", "Invalid Lead Status response format."},
   AccessDenied:                        errorDescription{"AccessDenied", "Access denied."},
   InvalidJWTResponseFormat:            errorDescription{"InvalidJWTResponseFormat", "Invalid JWT response format."},
   UnableToRefreshJWT:                  errorDescription{"UnableToRefreshJWT", "Unable to refresh JWT."},
@@ -111,6 +142,16 @@ var descriptions = map[ResponseError]errorDescription{
   ExpiredJWTToken:                     errorDescription{"ExpiredJWTToken", "Expired JWT token."},
   ExistingLead:                        errorDescription{"ExistingLead", "Existing Lead."},
   DuplicatedLead:                      errorDescription{"DuplicatedLead", "Duplicated Lead."},
+  QuotaExceeded:                       errorDescription{"QuotaExceeded", "Daily lead quota exceeded."},
+  CircuitOpen:                         errorDescription{"CircuitOpen", "Circuit breaker open, Kueski API is failing."},
+  InvalidRfc:                          errorDescription{"InvalidRfc", "Invalid RFC in full data."},
+  InvalidClabe:                        errorDescription{"InvalidClabe", "Invalid CLABE in full data."},
+  InvalidCardNumber:                   errorDescription{"InvalidCardNumber", "Invalid card number in full data."},
+  InvalidPhone:                        errorDescription{"InvalidPhone", "Invalid phone number in full data."},
+  InvalidPostalCode:                   errorDescription{"InvalidPostalCode", "Invalid or unknown postal code in full data."},
+  PostalCodeStateMismatch:             errorDescription{"PostalCodeStateMismatch", "Postal code is not in the lead state."},
+  LowQualityEmail:                     errorDescription{"LowQualityEmail", "Email is disposable, mistyped or a role account."},
+  InvalidFullDataFields:               errorDescription{"InvalidFullDataFields", "Full data fields failed their own validation."},
   GeneralError:                        errorDescription{"GeneralError", "General error."},
 }
 
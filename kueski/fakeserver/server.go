@@ -0,0 +1,227 @@
+// Package fakeserver implements an in-process fake of the Kueski Affiliates API for tests:
+// authentication, lead evaluation, lead data and lead status.
+package fakeserver
+
+import (
+  "encoding/json"
+  "fmt"
+  "io/ioutil"
+  "net/http"
+  "net/http/httptest"
+  "strconv"
+  "strings"
+  "sync"
+  "time"
+)
+
+// Token - JWT issued by the fake authentication endpoint.
+const Token string = "fake-jwt"
+
+// Lead - A lead evaluated by the fake server. Status follows the lead status endpoint values:
+// "in_progress", "approved", "rejected" or "funded".
+type Lead struct {
+  RequestID   string
+  Curp        string
+  Email       string
+  FullData    json.RawMessage
+  Status      string
+  Reason      string
+  EvaluatedAt time.Time
+  UpdatedAt   time.Time
+  FundedAt    time.Time
+}
+
+// Server - Fake Kueski API listening on a local address. Use URL as the client host.
+type Server struct {
+  *httptest.Server
+  leads  map[string]*Lead
+  curps  map[string]string
+  nextID int
+  now    func() time.Time
+  sync.Mutex
+}
+
+type leadStatusResponse struct {
+  RequestID   string `json:"request_id"`
+  Status      string `json:"status"`
+  Reason      string `json:"reason,omitempty"`
+  EvaluatedAt string `json:"evaluated_at"`
+  UpdatedAt   string `json:"updated_at"`
+  FundedAt    string `json:"funded_at,omitempty"`
+}
+
+// New - Starts a fake server. It must be closed with Close.
+func New() *Server {
+  server := &Server{leads: map[string]*Lead{}, curps: map[string]string{}, nextID: 1000, now: time.Now}
+  mux := http.NewServeMux()
+  mux.HandleFunc("/affiliates/authenticate", server.authenticate)
+  mux.HandleFunc("/affiliates/lead-evaluation", server.authorized(server.leadEvaluation))
+  mux.HandleFunc("/affiliates/lead-data", server.authorized(server.leadData))
+  mux.HandleFunc("/affiliates/lead-status", server.authorized(server.leadStatus))
+  server.Server = httptest.NewServer(mux)
+  return server
+}
+
+// Lead - A copy of the lead with the given request ID.
+func (server *Server) Lead(requestID string) (Lead, bool) {
+  server.Lock()
+  defer server.Unlock()
+
+  lead, ok := server.leads[requestID]
+
+  if !ok {
+    return Lead{}, false
+  }
+
+  return *lead, true
+}
+
+// SetStatus - Moves a lead to another status, as Kueski would while processing it.
+func (server *Server) SetStatus(requestID, status, reason string) error {
+  server.Lock()
+  defer server.Unlock()
+
+  lead, ok := server.leads[requestID]
+
+  if !ok {
+    return fmt.Errorf("fakeserver: unknown request ID %s", requestID)
+  }
+
+  lead.Status = status
+  lead.Reason = reason
+  lead.UpdatedAt = server.now().UTC().Truncate(time.Second)
+
+  if status == "funded" {
+    lead.FundedAt = lead.UpdatedAt
+  }
+
+  return nil
+}
+
+func (server *Server) authenticate(writer http.ResponseWriter, request *http.Request) {
+  if !strings.HasPrefix(request.Header.Get("Authorization"), "APIAuth ") {
+    writer.WriteHeader(http.StatusUnauthorized)
+    return
+  }
+
+  respond(writer, http.StatusOK, map[string]interface{}{"token": Token, "expiration": server.now().Add(time.Hour).Unix()})
+}
+
+func (server *Server) authorized(handler http.HandlerFunc) http.HandlerFunc {
+  return func(writer http.ResponseWriter, request *http.Request) {
+    if request.Header.Get("Authorization") != "Bearer "+Token {
+      writer.WriteHeader(http.StatusUnauthorized)
+      return
+    }
+
+    handler(writer, request)
+  }
+}
+
+func (server *Server) leadEvaluation(writer http.ResponseWriter, request *http.Request) {
+  var evaluation struct {
+    Curp  string `json:"curp"`
+    Email string `json:"email"`
+  }
+
+  body, _ := ioutil.ReadAll(request.Body)
+
+  if err := json.Unmarshal(body, &evaluation); err != nil {
+    writer.WriteHeader(http.StatusInternalServerError)
+    return
+  }
+
+  missing := []string{}
+
+  if evaluation.Email == "" {
+    missing = append(missing, "email is missing")
+  }
+
+  if evaluation.Curp == "" {
+    missing = append(missing, "curp is missing")
+  }
+
+  if len(missing) > 0 {
+    respond(writer, http.StatusBadRequest, map[string]string{"error": strings.Join(missing, ", ")})
+    return
+  }
+
+  server.Lock()
+  defer server.Unlock()
+
+  status := "approved"
+  requestID, existing := server.curps[evaluation.Curp]
+
+  if existing {
+    status = "existing"
+  } else {
+    server.nextID++
+    requestID = strconv.Itoa(server.nextID)
+    now := server.now().UTC().Truncate(time.Second)
+    server.leads[requestID] = &Lead{RequestID: requestID, Curp: evaluation.Curp, Email: evaluation.Email,
+      Status: "in_progress", EvaluatedAt: now, UpdatedAt: now}
+    server.curps[evaluation.Curp] = requestID
+  }
+
+  respond(writer, http.StatusCreated, map[string]string{
+    "curp": evaluation.Curp, "email": evaluation.Email, "request_id": requestID, "status": status,
+  })
+}
+
+func (server *Server) leadData(writer http.ResponseWriter, request *http.Request) {
+  var data struct {
+    FullData  json.RawMessage `json:"full_data"`
+    RequestID string          `json:"request_id"`
+  }
+
+  body, _ := ioutil.ReadAll(request.Body)
+
+  if err := json.Unmarshal(body, &data); err != nil {
+    writer.WriteHeader(http.StatusInternalServerError)
+    return
+  }
+
+  server.Lock()
+  defer server.Unlock()
+
+  lead, ok := server.leads[data.RequestID]
+
+  if !ok {
+    respond(writer, http.StatusBadRequest, map[string]string{"error": "Request not found"})
+    return
+  }
+
+  lead.FullData = data.FullData
+  respond(writer, http.StatusCreated, map[string]string{"response": "ok", "request_id": data.RequestID})
+}
+
+func (server *Server) leadStatus(writer http.ResponseWriter, request *http.Request) {
+  requestID := request.URL.Query().Get("request_id")
+
+  if requestID == "" {
+    respond(writer, http.StatusBadRequest, map[string]string{"error": "request_id is missing"})
+    return
+  }
+
+  lead, ok := server.Lead(requestID)
+
+  if !ok {
+    respond(writer, http.StatusNotFound, map[string]string{"error": "Request not found"})
+    return
+  }
+
+  response := leadStatusResponse{lead.RequestID, lead.Status, lead.Reason,
+    lead.EvaluatedAt.Format(time.RFC3339), lead.UpdatedAt.Format(time.RFC3339), ""}
+
+  if !lead.FundedAt.IsZero() {
+    response.FundedAt = lead.FundedAt.Format(time.RFC3339)
+  }
+
+  respond(writer, http.StatusOK, response)
+}
+
+func respond(writer http.ResponseWriter, statusCode int, body interface{}) {
+  writer.Header().Set("Content-Type", "application/json")
+  writer.WriteHeader(statusCode)
+  json.NewEncoder(writer).Encode(body)
+}
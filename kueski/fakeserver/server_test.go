@@ -0,0 +1,83 @@
+package fakeserver
+
+import (
+  "encoding/json"
+  "math/rand"
+  "net/http"
+  "strings"
+  "testing"
+
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski/util"
+  "github.com/stretchr/testify/assert"
+)
+
+func TestServerAuthorization(t *testing.T) {
+  server := New()
+  defer server.Close()
+
+  response, err := http.Post(server.URL+"/affiliates/authenticate", "application/json", nil)
+  assert.Nil(t, err)
+  assert.Equal(t, http.StatusUnauthorized, response.StatusCode)
+
+  response, err = http.Post(server.URL+"/affiliates/lead-evaluation", "application/json", strings.NewReader("{}"))
+  assert.Nil(t, err)
+  assert.Equal(t, http.StatusUnauthorized, response.StatusCode)
+}
+
+func TestServerLeads(t *testing.T) {
+  server := New()
+  defer server.Close()
+
+  post := func(path, body string) *http.Response {
+    request, _ := http.NewRequest("POST", server.URL+path, strings.NewReader(body))
+    request.Header.Set("Authorization", "Bearer "+Token)
+    response, _ := http.DefaultClient.Do(request)
+    return response
+  }
+
+  assert.Equal(t, http.StatusBadRequest, post("/affiliates/lead-evaluation", `{ "curp": "CURP" }`).StatusCode)
+  assert.Equal(t, http.StatusCreated, post("/affiliates/lead-evaluation", `{ "curp": "CURP", "email": "e@mail.com" }`).StatusCode)
+  assert.Equal(t, http.StatusBadRequest, post("/affiliates/lead-data", `{ "request_id": "1", "full_data": {} }`).StatusCode)
+  assert.Equal(t, http.StatusCreated, post("/affiliates/lead-data", `{ "request_id": "1001", "full_data": { "a": 1 } }`).StatusCode)
+
+  lead, ok := server.Lead("1001")
+  assert.True(t, ok)
+  assert.Equal(t, "CURP", lead.Curp)
+  assert.Equal(t, `{ "a": 1 }`, string(lead.FullData))
+
+  assert.Nil(t, server.SetStatus("1001", "rejected", "score"))
+  assert.NotNil(t, server.SetStatus("1", "rejected", "score"))
+}
+
+func TestServerEvaluatesRandomCurps(t *testing.T) {
+  server := New()
+  defer server.Close()
+
+  random := rand.New(rand.NewSource(1))
+  requestIDs := map[string]bool{}
+
+  for i := 0; i < 100; i++ {
+    curp := util.RandomCurp(random)
+    request, _ := http.NewRequest("POST", server.URL+"/affiliates/lead-evaluation",
+      strings.NewReader(`{ "curp": "`+curp+`", "email": "e@mail.com" }`))
+    request.Header.Set("Authorization", "Bearer "+Token)
+    response, err := http.DefaultClient.Do(request)
+
+    assert.Nil(t, err)
+    assert.Equal(t, http.StatusCreated, response.StatusCode)
+
+    var body struct {
+      RequestID string `json:"request_id"`
+    }
+
+    assert.Nil(t, json.NewDecoder(response.Body).Decode(&body))
+    response.Body.Close()
+
+    lead, ok := server.Lead(body.RequestID)
+    assert.True(t, ok)
+    assert.Equal(t, curp, lead.Curp)
+    requestIDs[body.RequestID] = true
+  }
+
+  assert.Len(t, requestIDs, 100)
+}
@@ -0,0 +1,123 @@
+package kueski
+
+import (
+  "time"
+
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski/breaker"
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski/util"
+)
+
+// Stage names reported to the Failed and PhaseCompleted hooks.
+// StageEvaluate       - The whole Evaluate call.
+// StageValidation     - Local validation of CURP, email and full data.
+// StageQuota          - Consumption of the daily lead quota.
+// StageAuthentication - JWT retrieval from the authentication endpoint.
+// StageLeadEvaluation - Call to the lead evaluation endpoint.
+// StageLeadData       - Call to the lead data endpoint.
+// StageLeadStatus     - The whole LeadStatus call.
+const (
+  StageEvaluate       string = "evaluate"
+  StageValidation     string = "validation"
+  StageQuota          string = "quota"
+  StageAuthentication string = "authentication"
+  StageLeadEvaluation string = "lead-evaluation"
+  StageLeadData       string = "lead-data"
+  StageLeadStatus     string = "lead-status"
+)
+
+// Hooks - Lifecycle callbacks invoked by the Client. Any of them can be left nil.
+// BeforeRequest       - Called before every API request, headers can be modified in place.
+// AfterResponse       - Called after every API request with the status code (zero on connection errors).
+// TokenRefreshed      - Called when a new JWT has been obtained, with its expiration.
+// LeadEvaluated       - Called after the lead evaluation step with the request ID and the outcome.
+// LeadDataSent        - Called after the full data step with the request ID and the outcome.
+// Failed              - Called whenever an Evaluate stage fails.
+// PhaseCompleted      - Called when a stage finishes, successfully or not, with its duration.
+// CircuitStateChanged - Called on every transition of the circuit breaker of a host, with the host URL.
+type Hooks struct {
+  BeforeRequest       func(method, url string, headers map[string]string, body []byte)
+  AfterResponse       func(method, url string, statusCode int, elapsed time.Duration, err error)
+  TokenRefreshed      func(expiration time.Time)
+  LeadEvaluated       func(requestID string, err error)
+  LeadDataSent        func(requestID string, err error)
+  Failed              func(stage string, err error)
+  PhaseCompleted      func(stage string, elapsed time.Duration, err error)
+  CircuitStateChanged func(host string, from, to breaker.State)
+}
+
+// AddHooks - Registers a set of lifecycle hooks. Hooks are called in registration order.
+func (client *Client) AddHooks(hooks Hooks) {
+  client.hooks = append(client.hooks, hooks)
+}
+
+func (client *Client) beforeRequest(method, url string, headers map[string]string, body []byte) {
+  for _, hooks := range client.hooks {
+    if hooks.BeforeRequest != nil {
+      hooks.BeforeRequest(method, url, headers, body)
+    }
+  }
+}
+
+func (client *Client) afterResponse(method, url string, response *util.Response, elapsed time.Duration, err error) {
+  statusCode := 0
+
+  if response != nil {
+    statusCode = response.StatusCode
+  }
+
+  for _, hooks := range client.hooks {
+    if hooks.AfterResponse != nil {
+      hooks.AfterResponse(method, url, statusCode, elapsed, err)
+    }
+  }
+}
+
+func (client *Client) tokenRefreshed(expiration time.Time) {
+  for _, hooks := range client.hooks {
+    if hooks.TokenRefreshed != nil {
+      hooks.TokenRefreshed(expiration)
+    }
+  }
+}
+
+func (client *Client) leadEvaluated(requestID string, err error) {
+  for _, hooks := range client.hooks {
+    if hooks.LeadEvaluated != nil {
+      hooks.LeadEvaluated(requestID, err)
+    }
+  }
+}
+
+func (client *Client) leadDataSent(requestID string, err error) {
+  for _, hooks := range client.hooks {
+    if hooks.LeadDataSent != nil {
+      hooks.LeadDataSent(requestID, err)
+    }
+  }
+}
+
+func (client *Client) failed(stage string, err error) {
+  for _, hooks := range client.hooks {
+    if hooks.Failed != nil {
+      hooks.Failed(stage, err)
+    }
+  }
+}
+
+func (client *Client) phaseCompleted(stage string, start time.Time, err error) {
+  elapsed := time.Since(start)
+
+  for _, hooks := range client.hooks {
+    if hooks.PhaseCompleted != nil {
+      hooks.PhaseCompleted(stage, elapsed, err)
+    }
+  }
+}
+
+func (client *Client) circuitStateChanged(host string, from, to breaker.State) {
+  for _, hooks := range client.hooks {
+    if hooks.CircuitStateChanged != nil {
+      hooks.CircuitStateChanged(host, from, to)
+    }
+  }
+}
@@ -0,0 +1,112 @@
+package kueski
+
+import (
+  "context"
+  "net/http"
+  "testing"
+  "time"
+
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski/errors"
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski/util"
+  "github.com/stretchr/testify/assert"
+)
+
+func TestRequestHooks(t *testing.T) {
+  calls := []string{}
+  client := Client{}
+  client.jwtProvider = &fakeTokenProvider{true}
+  client.url = "http://kueski.com"
+  client.requester = func(url string, headers map[string]string, body []byte) (*http.Response, error) {
+    assert.Equal(t, "Value", headers["X-Hooked"])
+    return buildHTTPResponse(201, ""), nil
+  }
+
+  client.AddHooks(Hooks{
+    BeforeRequest: func(method, url string, headers map[string]string, body []byte) {
+      calls = append(calls, "before "+method+" "+url)
+      headers["X-Hooked"] = "Value"
+    },
+  })
+  client.AddHooks(Hooks{
+    AfterResponse: func(method, url string, statusCode int, elapsed time.Duration, err error) {
+      assert.Equal(t, 201, statusCode)
+      assert.Nil(t, err)
+      calls = append(calls, "after "+url)
+    },
+  })
+
+  client.makeRequest(context.Background(), "path", []byte("Body"))
+
+  assert.Equal(t, []string{"before POST http://kueski.com/path", "after http://kueski.com/path"}, calls)
+}
+
+func TestEvaluateHooks(t *testing.T) {
+  evaluated := []error{}
+  sent := []error{}
+  failures := []string{}
+  validationErr := error(nil)
+  evaluationErr := error(nil)
+
+  client := Client{}
+  client.validator = func(curp, email string, fullData interface{}) error { return validationErr }
+  client.evaluator = func(ctx context.Context, client *Client, curp, email string) (string, error) { return "id", evaluationErr }
+  client.dataHandler = func(ctx context.Context, client *Client, jsonData interface{}, requestID string) error { return errors.RequestIDNotFound }
+  client.AddHooks(Hooks{
+    LeadEvaluated: func(requestID string, err error) { evaluated = append(evaluated, err) },
+    LeadDataSent:  func(requestID string, err error) { sent = append(sent, err) },
+    Failed:        func(stage string, err error) { failures = append(failures, stage+":"+err.Error()) },
+  })
+
+  client.Evaluate("", "", nil)
+  evaluationErr = errors.DuplicatedLead
+  client.Evaluate("", "", nil)
+  validationErr = errors.InvalidCurp
+  client.Evaluate("", "", nil)
+
+  assert.Equal(t, []error{nil, errors.DuplicatedLead}, evaluated)
+  assert.Equal(t, []error{errors.RequestIDNotFound}, sent)
+  assert.Equal(t, []string{"lead-data:RequestIDNotFound", "lead-evaluation:DuplicatedLead", "validation:InvalidCurp"}, failures)
+}
+
+func TestAuthenticationFailureHook(t *testing.T) {
+  failures := []string{}
+  client := Client{}
+  client.jwtProvider = &fakeTokenProvider{false}
+  client.validator = func(curp, email string, fullData interface{}) error { return nil }
+  client.evaluator = leadEvaluation
+  client.AddHooks(Hooks{Failed: func(stage string, err error) { failures = append(failures, stage+":"+err.Error()) }})
+
+  _, err := client.Evaluate("", "", nil)
+
+  assert.Equal(t, errors.GeneralError, err)
+  assert.Equal(t, []string{"authentication:GeneralError"}, failures)
+}
+
+func TestTokenRefreshedHook(t *testing.T) {
+  var refreshed time.Time
+  client := NewClient("URL", "Key", "Secret")
+  client.requester = func(url string, headers map[string]string, body []byte) (*http.Response, error) {
+    return buildHTTPResponse(201, `{"token": "Token", "expiration": 12345678}`), nil
+  }
+  client.AddHooks(Hooks{TokenRefreshed: func(expiration time.Time) { refreshed = expiration }})
+
+  token, err := client.jwtProvider.Token(client)
+
+  assert.Nil(t, err)
+  assert.Equal(t, "Token", token)
+  assert.Equal(t, time.Unix(12345678, 0), refreshed)
+}
+
+func TestUseMiddlewares(t *testing.T) {
+  client := NewClient("http://kueski.com", "Key", "Secret")
+  client.transport = util.RoundTripperFunc(func(request *http.Request) (*http.Response, error) {
+    assert.Equal(t, "Value", request.Header.Get("X-Middleware"))
+    return buildHTTPResponse(401, ""), nil
+  })
+
+  client.Use(util.HeaderMiddleware(map[string]string{"X-Middleware": "Value"}))
+  response, err := client.RequestToken()
+
+  assert.Nil(t, response)
+  assert.Equal(t, errors.AccessDenied, err)
+}
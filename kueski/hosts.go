@@ -0,0 +1,178 @@
+package kueski
+
+import (
+  "net/http"
+  "sync"
+  "time"
+
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski/breaker"
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski/errors"
+)
+
+// FailoverSettings - Host failover configuration.
+// FailureThreshold - Consecutive connection or authentication failures before a host is considered down, 1 when zero.
+// RecoveryInterval - Time a host stays down before it is probed again, 30 seconds when zero.
+type FailoverSettings struct {
+  FailureThreshold int
+  RecoveryInterval time.Duration
+}
+
+// HostStatus - Health of a configured host.
+// Circuit - State of the circuit breaker of the host, Closed when there is none.
+type HostStatus struct {
+  URL         string
+  Healthy     bool
+  Failures    int
+  LastFailure time.Time
+  Circuit     breaker.State
+}
+
+// apiHost - A Kueski API host with its own JWT provider, since tokens are only valid for the issuing host.
+// Its circuit breaker is kept by the Client, see SetCircuitBreaker.
+type apiHost struct {
+  url         string
+  tokens      TokenProvider
+  failures    int
+  lastFailure time.Time
+  downSince   time.Time
+}
+
+type hostPool struct {
+  hosts    []*apiHost
+  settings FailoverSettings
+  now      func() time.Time
+  sync.Mutex
+}
+
+// SetFailoverHosts - Configures secondary hosts (regional or DR) to fail over to when the primary
+// host given to NewClient is unreachable. Hosts are tried in order, and the client fails back to the
+// primary host as soon as it recovers. Each host authenticates separately and has its own circuit breaker.
+// Lead evaluation and lead data requests only fail over when they could not be sent, since a host may
+// have processed a request whose response was lost.
+func (client *Client) SetFailoverHosts(settings FailoverSettings, secondaries ...string) {
+  if settings.FailureThreshold <= 0 {
+    settings.FailureThreshold = 1
+  }
+
+  if settings.RecoveryInterval <= 0 {
+    settings.RecoveryInterval = 30 * time.Second
+  }
+
+  pool := &hostPool{settings: settings, now: time.Now}
+  pool.hosts = append(pool.hosts, &apiHost{url: client.url, tokens: client.jwtProvider})
+
+  for _, secondary := range secondaries {
+    provider := NewJWTProvider()
+    provider.SetLogger(client.log())
+    pool.hosts = append(pool.hosts, &apiHost{url: secondary, tokens: provider})
+
+    if client.breakerConf != nil && client.breakers[secondary] == nil {
+      client.breakers[secondary] = client.newBreaker(secondary)
+    }
+  }
+
+  client.hosts = pool
+}
+
+// HostHealth - Health of every configured host, primary first.
+func (client *Client) HostHealth() []HostStatus {
+  statuses := []HostStatus{}
+
+  if client.hosts == nil {
+    return append(statuses, HostStatus{URL: client.url, Healthy: true, Circuit: client.CircuitState()})
+  }
+
+  client.hosts.Lock()
+  defer client.hosts.Unlock()
+
+  for _, host := range client.hosts.hosts {
+    statuses = append(statuses, HostStatus{host.url, host.downSince.IsZero(), host.failures, host.lastFailure,
+      client.circuitState(host.url)})
+  }
+
+  return statuses
+}
+
+// candidateHosts - Hosts to try, in order: the available ones by priority, or all when none is.
+func (client *Client) candidateHosts() []*apiHost {
+  if client.hosts == nil {
+    return []*apiHost{client.primaryHost()}
+  }
+
+  return client.hosts.candidates()
+}
+
+// primaryHost - The host given to NewClient.
+func (client *Client) primaryHost() *apiHost {
+  if client.hosts == nil {
+    return &apiHost{url: client.url, tokens: client.jwtProvider}
+  }
+
+  return client.hosts.hosts[0]
+}
+
+func (pool *hostPool) candidates() []*apiHost {
+  pool.Lock()
+  defer pool.Unlock()
+
+  now := pool.now()
+  available := []*apiHost{}
+
+  for _, host := range pool.hosts {
+    if host.downSince.IsZero() || now.Sub(host.downSince) >= pool.settings.RecoveryInterval {
+      available = append(available, host)
+    }
+  }
+
+  if len(available) == 0 {
+    return append(available, pool.hosts...)
+  }
+
+  return available
+}
+
+// record - Updates the health of host, returning true if it has just gone down.
+func (pool *hostPool) record(host *apiHost, success bool) bool {
+  pool.Lock()
+  defer pool.Unlock()
+
+  if success {
+    host.failures = 0
+    host.downSince = time.Time{}
+    return false
+  }
+
+  host.failures++
+  host.lastFailure = pool.now()
+
+  if host.failures < pool.settings.FailureThreshold {
+    return false
+  }
+
+  wasUp := host.downSince.IsZero()
+  host.downSince = host.lastFailure
+  return wasUp
+}
+
+func (client *Client) recordHost(host *apiHost, success bool) {
+  if client.hosts != nil && client.hosts.record(host, success) {
+    client.log().Warn("kueski host down, failing over", "host", host.url)
+  }
+}
+
+// hostFailure - Whether err means the host could not be reached: connection errors, timeouts included,
+// and failed authentications. 5xx responses are left to the circuit breaker of the host, Kueski answers
+// some malformed leads with them.
+func hostFailure(err error) bool {
+  return err == errors.UnableToMakeConnection || err == errors.UnableToRefreshJWT
+}
+
+// failover - Whether the next host can be tried after err: the host could not be reached or its circuit
+// is open. Sent requests other than GETs are not tried again, the host may have processed them.
+func failover(err error, method string, sent bool) bool {
+  if sent && method != http.MethodGet {
+    return false
+  }
+
+  return hostFailure(err) || err == errors.CircuitOpen
+}
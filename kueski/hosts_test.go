@@ -0,0 +1,222 @@
+package kueski
+
+import (
+  "context"
+  "fmt"
+  "net/http"
+  "net/http/httptest"
+  "strings"
+  "testing"
+  "time"
+
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski/breaker"
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski/errors"
+  "github.com/stretchr/testify/assert"
+)
+
+// hostTokenProvider - Requests a new token on every call, so tests see which host issued it.
+type hostTokenProvider struct{}
+
+func (provider *hostTokenProvider) Token(accessor TokenAccessor) (string, error) {
+  token, err := accessor.RequestToken()
+  return string(token), err
+}
+
+func TestFailoverHosts(t *testing.T) {
+  primaryDown := true
+  calls := []string{}
+
+  client := NewClient("http://primary.kueski.com", "Key", "Secret")
+  client.requester = func(url string, headers map[string]string, body []byte) (*http.Response, error) {
+    calls = append(calls, url)
+
+    if primaryDown && strings.HasPrefix(url, "http://primary") {
+      return nil, errors.UnableToMakeConnection
+    }
+
+    if strings.HasSuffix(url, AuthenticatePath) {
+      return buildHTTPResponse(200, strings.Split(url, "/")[2]), nil
+    }
+
+    assert.True(t, strings.HasSuffix(headers[Authorization], strings.Split(url, "/")[2]))
+    return buildHTTPResponse(201, fmt.Sprintf(`{ "curp": "%s", "email": "%s", "request_id": "1", "status": "approved" }`, curp, email)), nil
+  }
+  client.SetFailoverHosts(FailoverSettings{RecoveryInterval: 20 * time.Millisecond}, "http://dr.kueski.com")
+
+  for _, host := range client.hosts.hosts {
+    host.tokens = &hostTokenProvider{}
+  }
+
+  requestID, err := leadEvaluation(context.Background(), client, curp, email)
+
+  assert.Nil(t, err)
+  assert.Equal(t, "1", requestID)
+  assert.Equal(t, []string{
+    "http://primary.kueski.com/" + AuthenticatePath,
+    "http://dr.kueski.com/" + AuthenticatePath,
+    "http://dr.kueski.com/" + leadEvaluationPath,
+  }, calls)
+
+  health := client.HostHealth()
+  assert.False(t, health[0].Healthy)
+  assert.Equal(t, 1, health[0].Failures)
+  assert.True(t, health[1].Healthy)
+
+  calls = []string{}
+  _, err = leadEvaluation(context.Background(), client, curp, email)
+
+  assert.Nil(t, err)
+  assert.Equal(t, 2, len(calls))
+  assert.True(t, strings.HasPrefix(calls[0], "http://dr"))
+
+  time.Sleep(20 * time.Millisecond)
+  primaryDown = false
+  calls = []string{}
+  _, err = leadEvaluation(context.Background(), client, curp, email)
+
+  assert.Nil(t, err)
+  assert.Equal(t, "http://primary.kueski.com/"+leadEvaluationPath, calls[1])
+  assert.True(t, client.HostHealth()[0].Healthy)
+}
+
+func TestFailoverHostsAllDown(t *testing.T) {
+  calls := 0
+
+  client := NewClient("http://primary.kueski.com", "Key", "Secret")
+  client.requester = func(url string, headers map[string]string, body []byte) (*http.Response, error) {
+    calls++
+    return nil, errors.UnableToMakeConnection
+  }
+  client.SetFailoverHosts(FailoverSettings{FailureThreshold: 1}, "http://dr.kueski.com")
+
+  for _, host := range client.hosts.hosts {
+    host.tokens = &fakeTokenProvider{true}
+  }
+
+  _, err := leadEvaluation(context.Background(), client, curp, email)
+  assert.Equal(t, errors.UnableToMakeConnection, err)
+
+  _, err = leadEvaluation(context.Background(), client, curp, email)
+  assert.Equal(t, errors.UnableToMakeConnection, err)
+  assert.Equal(t, 4, calls)
+}
+
+func TestFailoverHostsServerErrors(t *testing.T) {
+  calls := []string{}
+
+  client := NewClient("http://primary.kueski.com", "Key", "Secret")
+  client.requester = func(url string, headers map[string]string, body []byte) (*http.Response, error) {
+    calls = append(calls, strings.Split(url, "/")[2])
+    return buildHTTPResponse(500, `{ "error": "internal server error" }`), nil
+  }
+  client.SetFailoverHosts(FailoverSettings{}, "http://dr.kueski.com")
+
+  for _, host := range client.hosts.hosts {
+    host.tokens = &fakeTokenProvider{true}
+  }
+
+  for i := 0; i < 2; i++ {
+    _, err := leadEvaluation(context.Background(), client, curp, email)
+    assert.NotNil(t, err)
+  }
+
+  assert.Equal(t, []string{"primary.kueski.com", "primary.kueski.com"}, calls)
+  assert.True(t, client.HostHealth()[0].Healthy)
+  assert.Equal(t, 0, client.HostHealth()[0].Failures)
+}
+
+func TestSetFailoverHosts(t *testing.T) {
+  client := NewClient("http://primary.kueski.com", "Key", "Secret")
+  assert.Equal(t, []HostStatus{{URL: "http://primary.kueski.com", Healthy: true}}, client.HostHealth())
+
+  client.SetFailoverHosts(FailoverSettings{}, "http://dr.kueski.com")
+
+  assert.Equal(t, 1, client.hosts.settings.FailureThreshold)
+  assert.Equal(t, 30*time.Second, client.hosts.settings.RecoveryInterval)
+  assert.Equal(t, client.jwtProvider, client.hosts.hosts[0].tokens)
+  assert.NotEqual(t, client.jwtProvider, client.hosts.hosts[1].tokens)
+  assert.Equal(t, 2, len(client.HostHealth()))
+  assert.True(t, failover(errors.UnableToRefreshJWT, Method, false))
+  assert.True(t, failover(errors.CircuitOpen, Method, false))
+  assert.False(t, hostFailure(errors.CircuitOpen))
+  assert.True(t, failover(errors.UnableToMakeConnection, http.MethodGet, true))
+  assert.False(t, failover(errors.UnableToMakeConnection, Method, true))
+  assert.False(t, failover(errors.InvalidCurp, Method, false))
+}
+
+func TestFailoverHostsCircuitBreakers(t *testing.T) {
+  calls := []string{}
+
+  client := NewClient("http://primary.kueski.com", "Key", "Secret")
+  client.requester = func(url string, headers map[string]string, body []byte) (*http.Response, error) {
+    calls = append(calls, strings.Split(url, "/")[2])
+
+    if strings.HasPrefix(url, "http://primary") {
+      return nil, errors.UnableToMakeConnection
+    }
+
+    return buildHTTPResponse(201, fmt.Sprintf(`{ "curp": "%s", "email": "%s", "request_id": "1", "status": "approved" }`, curp, email)), nil
+  }
+  client.SetFailoverHosts(FailoverSettings{FailureThreshold: 5, RecoveryInterval: time.Minute}, "http://dr.kueski.com")
+  client.SetCircuitBreaker(breaker.Settings{FailureThreshold: 1, OpenTimeout: time.Minute})
+  collector := client.EnableMetrics()
+
+  for _, host := range client.hosts.hosts {
+    host.tokens = &fakeTokenProvider{true}
+  }
+
+  for i := 0; i < 3; i++ {
+    _, err := leadEvaluation(context.Background(), client, curp, email)
+    assert.Nil(t, err)
+  }
+
+  assert.Equal(t, []string{"primary.kueski.com", "dr.kueski.com", "dr.kueski.com", "dr.kueski.com"}, calls)
+
+  health := client.HostHealth()
+  assert.Equal(t, breaker.Open, health[0].Circuit)
+  assert.Equal(t, 1, health[0].Failures)
+  assert.Equal(t, breaker.Closed, health[1].Circuit)
+  assert.Equal(t, breaker.Open, client.CircuitState())
+  assert.Equal(t, 2.0, collector.circuitStates.Value("http://primary.kueski.com"))
+  assert.Equal(t, 0.0, collector.circuitStates.Value("http://dr.kueski.com"))
+  assert.Equal(t, 1.0, collector.transitions.Value("http://primary.kueski.com", "open"))
+
+  client.SetFailoverHosts(FailoverSettings{}, "http://dr.kueski.com", "http://backup.kueski.com")
+  assert.Equal(t, breaker.Open, client.HostHealth()[0].Circuit)
+  assert.NotNil(t, client.breakers["http://backup.kueski.com"])
+}
+
+func TestFailoverHostsSentRequests(t *testing.T) {
+  primaryCalls := 0
+  primary := httptest.NewServer(http.HandlerFunc(func(responseWriter http.ResponseWriter, request *http.Request) {
+    primaryCalls++
+    connection, _, _ := responseWriter.(http.Hijacker).Hijack()
+    connection.Close()
+  }))
+  defer primary.Close()
+
+  secondaryCalls := 0
+  secondary := httptest.NewServer(http.HandlerFunc(func(responseWriter http.ResponseWriter, request *http.Request) {
+    secondaryCalls++
+    responseWriter.WriteHeader(200)
+  }))
+  defer secondary.Close()
+
+  client := NewClient(primary.URL, "Key", "Secret")
+  client.SetFailoverHosts(FailoverSettings{}, secondary.URL)
+
+  for _, host := range client.hosts.hosts {
+    host.tokens = &fakeTokenProvider{true}
+  }
+
+  _, err := leadEvaluation(context.Background(), client, curp, email)
+  assert.Equal(t, errors.UnableToMakeConnection, err)
+  assert.Equal(t, 1, primaryCalls)
+  assert.Equal(t, 0, secondaryCalls)
+
+  client.hosts.hosts[0].downSince = time.Time{}
+  response, err := client.call(context.Background(), http.MethodGet, leadStatusPath, nil, nil)
+  assert.Nil(t, err)
+  assert.Equal(t, 200, response.StatusCode)
+  assert.Equal(t, 1, secondaryCalls)
+}
@@ -6,6 +6,7 @@ import (
   "time"
 
   "github.com/KueskiEngineering/go_affiliate-marketing/kueski/errors"
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski/logging"
 )
 
 // TokenProvider - Interface that defines the Token retrieval signature.
@@ -15,11 +16,17 @@ type TokenProvider interface {
 
 // JWTProvider - Structure that handles the JWT requests.
 type JWTProvider struct {
-  token string
-  exp   time.Time
+  token  string
+  exp    time.Time
+  logger logging.Logger
   sync.Mutex
 }
 
+// tokenListener - Optional interface of a TokenAccessor that wants to know about JWT renewals.
+type tokenListener interface {
+  tokenRefreshed(expiration time.Time)
+}
+
 type authenticateResponse struct {
   Token      string
   Expiration int
@@ -32,9 +39,15 @@ func NewJWTProvider() *JWTProvider {
   jwt := new(JWTProvider)
   jwt.token = ""
   jwt.exp = time.Now()
+  jwt.logger = logging.NopLogger{}
   return jwt
 }
 
+// SetLogger - Sets the structured logger, wrapped in the logging redaction layer.
+func (jwt *JWTProvider) SetLogger(logger logging.Logger) {
+  jwt.logger = logging.Redact(logger)
+}
+
 // Token - Provides a valid JSON web Token.
 func (jwt *JWTProvider) Token(client TokenAccessor) (string, error) {
   jwt.Lock()
@@ -42,20 +55,29 @@ func (jwt *JWTProvider) Token(client TokenAccessor) (string, error) {
 
   // Check if its JWT renewal time
   if jwt.token == "" || time.Until(jwt.exp).Minutes() < minutesToRenew {
+    jwt.log().Debug("kueski token renewal", "previous_expiration", jwt.exp)
     blob, err := client.RequestToken()
 
     if err != nil {
+      jwt.log().Error("kueski token renewal failed", "error", err)
       return "", err
     }
 
     token, expiration, err := jwt.parseResponse(blob)
 
     if err != nil {
+      jwt.log().Error("kueski token renewal failed", "error", err)
       return "", err
     }
 
     jwt.token = token
     jwt.exp = time.Unix(int64(expiration), 0)
+
+    if listener, ok := client.(tokenListener); ok {
+      listener.tokenRefreshed(jwt.exp)
+    }
+
+    jwt.log().Info("kueski token renewed", "expires_at", jwt.exp)
   }
 
   return jwt.token, nil
@@ -71,3 +93,11 @@ func (jwt *JWTProvider) parseResponse(blob []byte) (string, int, error) {
 
   return response.Token, response.Expiration, nil
 }
+
+func (jwt *JWTProvider) log() logging.Logger {
+  if jwt.logger == nil {
+    return logging.NopLogger{}
+  }
+
+  return jwt.logger
+}
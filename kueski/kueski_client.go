@@ -1,11 +1,21 @@
 package kueski
 
 import (
+  "bytes"
+  "context"
   "encoding/json"
   "fmt"
   "net/http"
+  "net/http/httptrace"
+  "net/url"
+  "sync/atomic"
+  "time"
 
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski/breaker"
   "github.com/KueskiEngineering/go_affiliate-marketing/kueski/errors"
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski/limits"
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski/logging"
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski/tracing"
   "github.com/KueskiEngineering/go_affiliate-marketing/kueski/util"
 )
 
@@ -17,10 +27,23 @@ type Client struct {
   apiKey      string
   secretKey   string
   requester   util.PostRequestFunc
+  httpRequest util.Requester
   validator   leadValidator
+  rules       []Rule
   evaluator   leadEvaluator
   dataHandler leadDataHandler
   jwtProvider TokenProvider
+  httpClient  *http.Client
+  transport   http.RoundTripper
+  middlewares []util.Middleware
+  hooks       []Hooks
+  tracer      tracing.Tracer
+  logger      logging.Logger
+  limiter     *limits.TokenBucket
+  quota       *limits.QuotaTracker
+  hosts       *hostPool
+  breakers    map[string]*breaker.Breaker
+  breakerConf *breaker.Settings
 }
 
 type apiError struct {
@@ -40,10 +63,14 @@ func NewClient(url, apiKey, secretKey string) *Client {
   client.url = url
   client.apiKey = apiKey
   client.secretKey = secretKey
-  client.requester = util.PostRequest
+  client.transport = http.DefaultTransport
+  client.httpClient = &http.Client{Transport: client.transport}
+  client.httpRequest = util.NewRequester(client.httpClient, util.DefaultMaxBodySize)
   client.evaluator = leadEvaluation
   client.dataHandler = leadData
   client.jwtProvider = NewJWTProvider()
+  client.tracer = tracing.NoopTracer{}
+  client.logger = logging.NopLogger{}
 
   validator := Validator{util.ValidateCurp, util.ValidateEmail, util.ValidateFullData}
   client.validator = validator.validate
@@ -66,21 +93,68 @@ func NewClient(url, apiKey, secretKey string) *Client {
 // * Identify if response is successful, otherwise return proper error code.
 // * Return Request ID if all successful.
 func (client *Client) Evaluate(curp, email string, fullData interface{}) (string, error) {
+  return client.EvaluateContext(context.Background(), curp, email, fullData)
+}
+
+// EvaluateContext - Performs the lead evaluation as Evaluate does.
+// ctx - Carries the parent span, if any, of the spans opened during the evaluation.
+func (client *Client) EvaluateContext(ctx context.Context, curp, email string, fullData interface{}) (string, error) {
+  ctx, end := client.startStage(ctx, StageEvaluate)
+  requestID, err := client.evaluate(ctx, curp, email, fullData)
+
+  if span := tracing.SpanFromContext(ctx); span != nil && requestID != "" {
+    span.SetAttribute(requestIDAttribute, requestID)
+  }
+
+  if err != nil {
+    client.log().Warn("kueski evaluation failed", "request_id", requestID, "curp", curp, "email", email, "error", err)
+  } else {
+    client.log().Info("kueski lead evaluated", "request_id", requestID, "curp", curp, "email", email)
+  }
+
+  end(err)
+
+  return requestID, err
+}
+
+func (client *Client) evaluate(ctx context.Context, curp, email string, fullData interface{}) (string, error) {
   // Validate data to POST before calling the API.
-  err := client.validator(curp, email, fullData)
+  _, end := client.startStage(ctx, StageValidation)
+  err := client.validate(curp, email, fullData)
+  end(err)
+
+  if err != nil {
+    return "", err
+  }
+
+  _, end = client.startStage(ctx, StageQuota)
+  quotaDay, err := client.consumeQuota()
+  end(err)
 
   if err != nil {
     return "", err
   }
 
   // Calls to the Kueski API.
-  requestID, err := client.evaluator(client, curp, email)
+  stageCtx, end := client.startStage(ctx, StageLeadEvaluation)
+  stageCtx, sent := withSentMarker(stageCtx)
+  requestID, err := client.evaluator(stageCtx, client, curp, email)
+  client.leadEvaluated(requestID, err)
+  end(err)
 
   if err != nil {
+    // The lead never reached Kueski, e.g. on connection errors or an open circuit, so it does not count.
+    if !*sent {
+      client.refundQuota(quotaDay)
+    }
+
     return requestID, err
   }
 
-  err = client.dataHandler(client, fullData, requestID)
+  stageCtx, end = client.startStage(ctx, StageLeadData)
+  err = client.dataHandler(stageCtx, client, fullData, requestID)
+  client.leadDataSent(requestID, err)
+  end(err)
 
   if err != nil {
     return requestID, err
@@ -89,20 +163,211 @@ func (client *Client) Evaluate(curp, email string, fullData interface{}) (string
   return requestID, nil
 }
 
-func (client *Client) makeRequest(path string, body []byte) (*http.Response, error) {
-  token, err := client.jwtProvider.Token(client)
+// Use - Chains middlewares around the HTTP transport used for every API call, including authentication.
+// The first middleware given is the outermost one. Later calls add their middlewares inside the previous ones,
+// closer to the transport.
+func (client *Client) Use(middlewares ...util.Middleware) {
+  client.middlewares = append(client.middlewares, middlewares...)
+  client.HTTPClient().Transport = util.Chain(client.transport, client.middlewares...)
+}
+
+// SetRequester - Replaces the requester of every API call, e.g. by util.NewRequester with another body limit.
+// Middlewares and transport settings only apply if it is built on the client's HTTP client.
+func (client *Client) SetRequester(requester util.Requester) {
+  client.requester = nil
+  client.httpRequest = requester
+}
+
+// HTTPClient - HTTP client used by the default requester, with the transport and middlewares configured.
+// It is created, with its requester, on first use when the Client was not built by NewClient.
+func (client *Client) HTTPClient() *http.Client {
+  if client.httpClient == nil {
+    client.httpClient = &http.Client{Transport: util.Chain(client.transport, client.middlewares...)}
+
+    if client.httpRequest == nil {
+      client.httpRequest = util.NewRequester(client.httpClient, util.DefaultMaxBodySize)
+    }
+  }
+
+  return client.httpClient
+}
+
+// SetTransport - Replaces the HTTP transport with one using the given proxy, root CAs, pinned keys
+// and client certificate. Middlewares already added keep wrapping it.
+func (client *Client) SetTransport(settings util.TransportSettings) error {
+  transport, err := util.NewTransport(settings)
+
+  if err != nil {
+    return err
+  }
+
+  client.transport = transport
+  client.HTTPClient().Transport = util.Chain(client.transport, client.middlewares...)
+  return nil
+}
+
+// SetLogger - Sets the structured logger used by the client and its JWT provider.
+// Entries go through the logging redaction layer: CURPs and emails are masked,
+// credentials, JWTs and full data are dropped. Pass a logging.Redactor to hash identifiers instead.
+func (client *Client) SetLogger(logger logging.Logger) {
+  client.logger = logging.Redact(logger)
+
+  if provider, ok := client.jwtProvider.(*JWTProvider); ok {
+    provider.SetLogger(client.logger)
+  }
+}
+
+func (client *Client) log() logging.Logger {
+  if client.logger == nil {
+    return logging.NopLogger{}
+  }
+
+  return client.logger
+}
+
+func (client *Client) makeRequest(ctx context.Context, path string, body []byte) (*util.Response, error) {
+  return client.call(ctx, Method, path, nil, body)
+}
+
+// call - Sends an authenticated request to the API, failing over to the next host when the request
+// could not be sent to the current one.
+func (client *Client) call(ctx context.Context, method, path string, query url.Values, body []byte) (*util.Response, error) {
+  var response *util.Response
+  var err error
+
+  for _, host := range client.candidateHosts() {
+    var sent bool
+    response, sent, err = client.callHost(ctx, host, method, path, query, body)
+
+    if !failover(err, method, sent) {
+      break
+    }
+  }
+
+  return response, err
+}
+
+// callHost - Sends an authenticated request to host, reporting whether the request, authentication
+// aside, was sent.
+func (client *Client) callHost(ctx context.Context, host *apiHost, method, path string, query url.Values, body []byte) (*util.Response, bool, error) {
+  tokenCtx, end := client.startStage(ctx, StageAuthentication)
+  token, err := host.tokens.Token(&contextTokenAccessor{client, tokenCtx, host})
+  end(err)
 
   if err != nil {
-    return nil, err
+    if err != errors.CircuitOpen && !canceled(ctx, err) {
+      client.recordHost(host, !hostFailure(err))
+    }
+
+    return nil, false, err
   }
 
-  url := util.BuildURL(client.url, path)
   headers := map[string]string{
     Authorization: fmt.Sprintf(tokenHeaderFormat, token),
     ContentType:   ApplicationJSON,
   }
 
-  return client.requester(url, headers, body)
+  request := &util.Request{Method: method, URL: util.BuildURL(host.url, path), Query: query, Headers: headers}
+  response, sent, err := client.do(ctx, client.breakers[host.url], request, body)
+
+  if err != errors.CircuitOpen && !canceled(ctx, err) {
+    client.recordHost(host, !hostFailure(err))
+  }
+
+  if marker, ok := ctx.Value(sentKey{}).(*bool); ok && sent {
+    *marker = true
+  }
+
+  return response, sent, err
+}
+
+type sentKey struct{}
+
+// withSentMarker - Context whose marker is set once an API call, authentication aside, is sent.
+func withSentMarker(ctx context.Context) (context.Context, *bool) {
+  sent := new(bool)
+  return context.WithValue(ctx, sentKey{}, sent), sent
+}
+
+// do - Sends the request through the circuit breaker of its host, rate limiter, tracing, hooks and logging.
+// The body is given apart so it can be reported to the hooks. Reports whether the request was sent:
+// written to the connection, or answered when the requester cannot tell.
+func (client *Client) do(ctx context.Context, circuit *breaker.Breaker, request *util.Request, body []byte) (*util.Response, bool, error) {
+  if circuit != nil {
+    if err := circuit.Allow(); err != nil {
+      return nil, false, err
+    }
+  }
+
+  if client.limiter != nil {
+    if err := client.limiter.Wait(ctx); err != nil {
+      if circuit != nil {
+        circuit.Release()
+      }
+
+      return nil, false, err
+    }
+  }
+
+  if err := ctx.Err(); err != nil {
+    if circuit != nil {
+      circuit.Release()
+    }
+
+    return nil, false, err
+  }
+
+  url := request.URL
+  ctx, span := client.startSpan(ctx, fmt.Sprintf("%s %s", request.Method, endpointName(url)))
+  defer span.End()
+
+  span.SetAttribute(methodAttribute, request.Method)
+  span.SetAttribute(urlAttribute, url)
+  span.SetAttribute(endpointAttribute, endpointName(url))
+  tracing.Inject(ctx, request.Headers)
+  client.beforeRequest(request.Method, url, request.Headers, body)
+  client.log().Debug("kueski request", "method", request.Method, "url", url, "headers", request.Headers)
+
+  var written int32
+  request.Context = httptrace.WithClientTrace(ctx, &httptrace.ClientTrace{
+    WroteRequest: func(httptrace.WroteRequestInfo) { atomic.StoreInt32(&written, 1) },
+  })
+  request.Body = bytes.NewReader(body)
+
+  start := time.Now()
+  response, err := client.requesterFor().Do(request)
+  elapsed := time.Since(start)
+  client.afterResponse(request.Method, url, response, elapsed, err)
+
+  if circuit != nil && canceled(ctx, err) {
+    circuit.Release()
+  } else if circuit != nil {
+    circuit.Record(!circuitFailure(response, err))
+  }
+
+  if response != nil {
+    span.SetAttribute(statusCodeAttribute, response.StatusCode)
+    client.log().Debug("kueski response", "url", url, "status", response.StatusCode, "elapsed", elapsed)
+  } else {
+    client.log().Error("kueski request failed", "url", url, "elapsed", elapsed, "error", err)
+  }
+
+  recordSpanError(span, err)
+
+  return response, response != nil || atomic.LoadInt32(&written) == 1, err
+}
+
+// requesterFor - The injected PostRequestFunc when there is one, the HTTP client requester otherwise.
+func (client *Client) requesterFor() util.Requester {
+  if client.requester != nil {
+    return util.PostRequester(client.requester)
+  }
+
+  if client.httpRequest == nil {
+    client.httpRequest = util.NewRequester(client.HTTPClient(), util.DefaultMaxBodySize)
+  }
+
+  return client.httpRequest
 }
 
 func resolveAPIError(body []byte, malformedError error, errorMap map[string]error) error {
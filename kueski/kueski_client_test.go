@@ -1,10 +1,13 @@
 package kueski
 
 import (
+  "context"
   "net/http"
+  "net/http/httptest"
   "testing"
 
   "github.com/KueskiEngineering/go_affiliate-marketing/kueski/errors"
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski/util"
   "github.com/stretchr/testify/assert"
 )
 
@@ -32,7 +35,7 @@ func TestMakeRequestErrors(t *testing.T) {
   client := Client{}
   client.jwtProvider = &fakeTokenProvider{false}
 
-  response, err := client.makeRequest("path", []byte("body"))
+  response, err := client.makeRequest(context.Background(), "path", []byte("body"))
 
   assert.Nil(t, response)
   assert.Equal(t, errors.GeneralError, err)
@@ -55,7 +58,7 @@ func TestMakeRequest(t *testing.T) {
   client.url = "http://kueski.com"
   client.requester = requester
 
-  response, err := client.makeRequest("path", []byte("Body"))
+  response, err := client.makeRequest(context.Background(), "path", []byte("Body"))
 
   assert.Nil(t, response)
   assert.Equal(t, errors.GeneralError, err)
@@ -85,7 +88,7 @@ func TestEvaluate(t *testing.T) {
     return errors.InvalidCurpAndEmail
   }
 
-  fakeEvaluator := func(client *Client, curp, email string) (string, error) {
+  fakeEvaluator := func(ctx context.Context, client *Client, curp, email string) (string, error) {
     assert.Equal(t, &testClient, client)
     assert.Equal(t, testCurp, curp)
     assert.Equal(t, testEmail, email)
@@ -97,7 +100,7 @@ func TestEvaluate(t *testing.T) {
     return testRequestID, errors.DuplicatedLead
   }
 
-  fakeDataHandler := func(client *Client, jsonData interface{}, requestID string) error {
+  fakeDataHandler := func(ctx context.Context, client *Client, jsonData interface{}, requestID string) error {
     assert.Equal(t, &testClient, client)
     assert.Equal(t, testData, jsonData)
     assert.Equal(t, testRequestID, requestID)
@@ -136,3 +139,74 @@ func TestEvaluate(t *testing.T) {
   assert.Equal(t, testRequestID, returnedRequestID)
   assert.Nil(t, err)
 }
+
+func TestSetTransport(t *testing.T) {
+  client := NewClient("http://kueski.com", "Key", "Secret")
+  client.Use(util.HeaderMiddleware(map[string]string{"X-Middleware": "Value"}))
+
+  assert.NotNil(t, client.SetTransport(util.TransportSettings{RootCAFile: "/does/not/exist.pem"}))
+  assert.Equal(t, http.DefaultTransport, client.transport)
+
+  assert.Nil(t, client.SetTransport(util.TransportSettings{ProxyURL: "http://proxy.corp:3128"}))
+  _, ok := client.transport.(*http.Transport)
+  assert.True(t, ok)
+  _, ok = client.httpClient.Transport.(util.RoundTripperFunc)
+  assert.True(t, ok)
+
+  unbuilt := Client{}
+  assert.Nil(t, unbuilt.SetTransport(util.TransportSettings{ProxyURL: "http://proxy.corp:3128"}))
+  assert.Equal(t, unbuilt.transport, unbuilt.httpClient.Transport)
+}
+
+func TestUseWithoutNewClient(t *testing.T) {
+  ts := httptest.NewServer(http.HandlerFunc(func(responseWriter http.ResponseWriter, request *http.Request) {
+    assert.Equal(t, "Value", request.Header.Get("X-Middleware"))
+    responseWriter.WriteHeader(200)
+  }))
+  defer ts.Close()
+
+  client := Client{url: ts.URL}
+  client.jwtProvider = &fakeTokenProvider{true}
+  client.Use(util.HeaderMiddleware(map[string]string{"X-Middleware": "Value"}))
+
+  response, err := client.call(context.Background(), "GET", "path", nil, nil)
+
+  assert.Nil(t, err)
+  assert.Equal(t, 200, response.StatusCode)
+  assert.NotNil(t, client.httpClient)
+}
+
+func TestRequesterForWithoutRequester(t *testing.T) {
+  ts := httptest.NewServer(http.HandlerFunc(func(responseWriter http.ResponseWriter, request *http.Request) {
+    responseWriter.WriteHeader(200)
+  }))
+  defer ts.Close()
+
+  client := Client{url: ts.URL}
+  client.jwtProvider = &fakeTokenProvider{true}
+  client.httpClient = &http.Client{}
+
+  response, err := client.call(context.Background(), "GET", "path", nil, nil)
+
+  assert.Nil(t, err)
+  assert.Equal(t, 200, response.StatusCode)
+}
+
+func TestSetRequester(t *testing.T) {
+  client := NewClient("http://kueski.com", "Key", "Secret")
+  client.jwtProvider = &fakeTokenProvider{true}
+  client.SetRequester(util.RequesterFunc(func(request *util.Request) (*util.Response, error) {
+    assert.Equal(t, "GET", request.Method)
+    assert.Equal(t, "http://kueski.com/path", request.URL)
+    assert.Equal(t, "1", request.Query.Get("page"))
+    assert.Equal(t, "Bearer Token", request.Headers[Authorization])
+    return util.WrapResponse(buildHTTPResponse(200, "Body")), nil
+  }))
+
+  response, err := client.call(context.Background(), "GET", "path", map[string][]string{"page": {"1"}}, nil)
+  assert.Nil(t, err)
+
+  body, _ := response.ReadBody()
+  assert.Equal(t, "Body", string(body))
+  assert.Equal(t, client.httpClient, client.HTTPClient())
+}
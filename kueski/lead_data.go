@@ -1,10 +1,10 @@
 package kueski
 
 import (
+  "context"
   "encoding/json"
 
   "github.com/KueskiEngineering/go_affiliate-marketing/kueski/errors"
-  "github.com/KueskiEngineering/go_affiliate-marketing/kueski/util"
 )
 
 // leadDataPath - Path to the endpoint where to post lead full data.
@@ -33,17 +33,17 @@ var leadDataErrors = map[string]error{
 }
 
 // leadDataHandler - Type that defines the lead data handler signature.
-type leadDataHandler func(client *Client, jsonData interface{}, requestID string) error
+type leadDataHandler func(ctx context.Context, client *Client, jsonData interface{}, requestID string) error
 
-func leadData(client *Client, jsonData interface{}, requestID string) error {
+func leadData(ctx context.Context, client *Client, jsonData interface{}, requestID string) error {
   body, _ := json.Marshal(leadFullData{jsonData, requestID})
-  response, err := client.makeRequest(leadDataPath, body)
+  response, err := client.makeRequest(ctx, leadDataPath, body)
 
   if err != nil {
     return err
   }
 
-  responseBody, err := util.ExtractBody(response)
+  responseBody, err := response.ReadBody()
 
   if err != nil {
     return errors.InvalidLeadDataResponseFormat
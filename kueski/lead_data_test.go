@@ -1,6 +1,7 @@
 package kueski
 
 import (
+  "context"
   "fmt"
   "net/http"
   "testing"
@@ -37,7 +38,7 @@ func TestLeadDataHandlerRequestError(t *testing.T) {
   data := sampleData{"Fake Name", 1}
   requestID := "0987654321"
 
-  err := leadData(&client, data, requestID)
+  err := leadData(context.Background(), &client, data, requestID)
   assert.Equal(t, errors.GeneralError, err)
 }
 
@@ -85,7 +86,7 @@ func TestLeadDataResponseErrors(t *testing.T) {
     requester := func(url string, headers map[string]string, body []byte) (*http.Response, error) { return response, nil }
 
     client.requester = requester
-    dataErr := leadData(&client, data, requestID)
+    dataErr := leadData(context.Background(), &client, data, requestID)
 
     assert.Equal(t, err, dataErr)
   }
@@ -1,10 +1,10 @@
 package kueski
 
 import (
+  "context"
   "encoding/json"
 
   "github.com/KueskiEngineering/go_affiliate-marketing/kueski/errors"
-  "github.com/KueskiEngineering/go_affiliate-marketing/kueski/util"
 )
 
 type evaluation struct {
@@ -36,17 +36,17 @@ var evaluationErrors = map[string]error{
 var validResponseStatus = map[string]bool{"approved": true, "duplicated": true, "existing": true}
 
 // leadEvaluator - Type that defines the lead evaluation signature.
-type leadEvaluator func(client *Client, curp, email string) (string, error)
+type leadEvaluator func(ctx context.Context, client *Client, curp, email string) (string, error)
 
-func leadEvaluation(client *Client, curp, email string) (string, error) {
+func leadEvaluation(ctx context.Context, client *Client, curp, email string) (string, error) {
   body, _ := json.Marshal(evaluation{curp, email})
-  response, err := client.makeRequest(leadEvaluationPath, body)
+  response, err := client.makeRequest(ctx, leadEvaluationPath, body)
 
   if err != nil {
     return "", err
   }
 
-  responseBody, err := util.ExtractBody(response)
+  responseBody, err := response.ReadBody()
 
   if err != nil {
     return "", errors.InvalidLeadEvaluationResponseFormat
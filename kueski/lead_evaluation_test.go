@@ -1,6 +1,7 @@
 package kueski
 
 import (
+  "context"
   "fmt"
   "net/http"
   "testing"
@@ -51,7 +52,7 @@ func TestLeadEvaluation(t *testing.T) {
     requester := func(url string, headers map[string]string, body []byte) (*http.Response, error) { return response, nil }
 
     client.requester = requester
-    responseGot, dataErr := leadEvaluation(&client, curp, email)
+    responseGot, dataErr := leadEvaluation(context.Background(), &client, curp, email)
 
     expected := ""
     if i == len(responses)-1 {
@@ -67,7 +68,7 @@ func TestLeadEvaluationRequestError(t *testing.T) {
   client := Client{}
   client.jwtProvider = &fakeTokenProvider{false}
 
-  _, err := leadEvaluation(&client, curp, email)
+  _, err := leadEvaluation(context.Background(), &client, curp, email)
   assert.Equal(t, errors.GeneralError, err)
 }
 
@@ -0,0 +1,155 @@
+package kueski
+
+import (
+  "regexp"
+  "strings"
+  "time"
+
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski/util"
+)
+
+// EmploymentStatus - Employment situation of the lead.
+type EmploymentStatus string
+
+// Employment statuses.
+const (
+  Employed     EmploymentStatus = "employed"
+  SelfEmployed EmploymentStatus = "self_employed"
+  Unemployed   EmploymentStatus = "unemployed"
+  Retired      EmploymentStatus = "retired"
+  Student      EmploymentStatus = "student"
+)
+
+// LeadProfile - Typed full data for Evaluate. Evaluate validates it with Validate before any call,
+// failing with a util.FullDataFieldsError wrapping the LeadProfileError. Amounts are in whole pesos, dates are YYYY-MM-DD.
+type LeadProfile struct {
+  FirstName       string      `json:"first_name"`
+  PaternalSurname string      `json:"paternal_surname"`
+  MaternalSurname string      `json:"maternal_surname,omitempty"`
+  BirthDate       string      `json:"birth_date"`
+  Phone           util.Phone  `json:"phone"`
+  Rfc             util.Rfc    `json:"rfc,omitempty"`
+  Address         Address     `json:"address"`
+  MonthlyIncome   int64       `json:"monthly_income"`
+  Employment      Employment  `json:"employment"`
+  RequestedAmount int64       `json:"requested_amount"`
+  Consent         Consent     `json:"consent"`
+  Attribution     Attribution `json:"attribution"`
+}
+
+// Address - Residence address of the lead. State is the name or RENAPO code.
+type Address struct {
+  Street         string `json:"street"`
+  ExteriorNumber string `json:"exterior_number"`
+  InteriorNumber string `json:"interior_number,omitempty"`
+  Colonia        string `json:"colonia"`
+  Municipality   string `json:"municipality"`
+  State          string `json:"state"`
+  PostalCode     string `json:"postal_code"`
+}
+
+// Employment - Employment of the lead. Employer is required for Employed leads.
+type Employment struct {
+  Status         EmploymentStatus `json:"status"`
+  Employer       string           `json:"employer,omitempty"`
+  Occupation     string           `json:"occupation,omitempty"`
+  MonthsEmployed int              `json:"months_employed,omitempty"`
+}
+
+// Consent - Acceptances given by the lead. The terms and the privacy notice must be accepted.
+type Consent struct {
+  TermsAccepted          bool      `json:"terms_accepted"`
+  PrivacyNoticeAccepted  bool      `json:"privacy_notice_accepted"`
+  CreditBureauAuthorized bool      `json:"credit_bureau_authorized"`
+  AcceptedAt             time.Time `json:"accepted_at"`
+  IP                     string    `json:"ip,omitempty"`
+}
+
+// Attribution - Where the affiliate got the lead from, all optional.
+type Attribution struct {
+  Source   string `json:"source,omitempty"`
+  Medium   string `json:"medium,omitempty"`
+  Campaign string `json:"campaign,omitempty"`
+  ClickID  string `json:"click_id,omitempty"`
+}
+
+// FieldError - A LeadProfile field failing validation, by its JSON path, e.g. address.postal_code.
+type FieldError struct {
+  Field   string
+  Message string
+}
+
+// LeadProfileError - The fields of a LeadProfile failing validation, in struct order.
+type LeadProfileError []FieldError
+
+func (err LeadProfileError) Error() string {
+  fields := []string{}
+
+  for _, field := range err {
+    fields = append(fields, field.Field+": "+field.Message)
+  }
+
+  return "invalid lead profile: " + strings.Join(fields, "; ")
+}
+
+// minAge - Legal age, the youngest leads Kueski lends to.
+const minAge int = 18
+
+var postalCodeRegex = regexp.MustCompile(`^\d{5}$`)
+
+var employmentStatuses = map[EmploymentStatus]bool{
+  Employed: true, SelfEmployed: true, Unemployed: true, Retired: true, Student: true,
+}
+
+// Validate - Field-level validation, returns a LeadProfileError with every field failing, nil if valid.
+func (profile LeadProfile) Validate() error {
+  return profile.validate(time.Now())
+}
+
+func (profile LeadProfile) validate(now time.Time) error {
+  fields := LeadProfileError{}
+  check := func(valid bool, field, message string) {
+    if !valid {
+      fields = append(fields, FieldError{field, message})
+    }
+  }
+
+  check(strings.TrimSpace(profile.FirstName) != "", "first_name", "is missing")
+  check(strings.TrimSpace(profile.PaternalSurname) != "", "paternal_surname", "is missing")
+
+  birthDate, err := time.Parse("2006-01-02", profile.BirthDate)
+  check(err == nil, "birth_date", "is not a YYYY-MM-DD date")
+
+  if err == nil {
+    age := util.Age(birthDate, now)
+    check(age >= minAge, "birth_date", "is under the legal age")
+  }
+
+  check(util.ValidatePhone(string(profile.Phone)), "phone", "is not a valid Mexican phone number")
+  check(profile.Rfc == "" || util.ValidateRfc(string(profile.Rfc)), "rfc", "is not a valid RFC")
+
+  check(strings.TrimSpace(profile.Address.Street) != "", "address.street", "is missing")
+  check(strings.TrimSpace(profile.Address.ExteriorNumber) != "", "address.exterior_number", "is missing")
+  check(strings.TrimSpace(profile.Address.Colonia) != "", "address.colonia", "is missing")
+  check(strings.TrimSpace(profile.Address.Municipality) != "", "address.municipality", "is missing")
+  check(strings.TrimSpace(profile.Address.State) != "", "address.state", "is missing")
+  check(postalCodeRegex.MatchString(profile.Address.PostalCode), "address.postal_code", "is not 5 digits")
+
+  check(profile.MonthlyIncome > 0, "monthly_income", "must be positive")
+  check(employmentStatuses[profile.Employment.Status], "employment.status", "is not a known status")
+  check(profile.Employment.Status != Employed || strings.TrimSpace(profile.Employment.Employer) != "",
+    "employment.employer", "is missing")
+  check(profile.Employment.MonthsEmployed >= 0, "employment.months_employed", "must not be negative")
+  check(profile.RequestedAmount > 0, "requested_amount", "must be positive")
+
+  check(profile.Consent.TermsAccepted, "consent.terms_accepted", "must be accepted")
+  check(profile.Consent.PrivacyNoticeAccepted, "consent.privacy_notice_accepted", "must be accepted")
+  check(!profile.Consent.AcceptedAt.IsZero() && !profile.Consent.AcceptedAt.After(now), "consent.accepted_at",
+    "is missing or in the future")
+
+  if len(fields) > 0 {
+    return fields
+  }
+
+  return nil
+}
@@ -0,0 +1,109 @@
+package kueski
+
+import (
+  "encoding/json"
+  "testing"
+  "time"
+
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski/errors"
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski/util"
+  "github.com/stretchr/testify/assert"
+)
+
+var profileNow = time.Date(2020, 6, 15, 12, 0, 0, 0, time.UTC)
+
+func validProfile() LeadProfile {
+  return LeadProfile{
+    FirstName:       "Juan",
+    PaternalSurname: "Pérez",
+    MaternalSurname: "López",
+    BirthDate:       "1990-05-15",
+    Phone:           "33 1234 5678",
+    Rfc:             "GODE561231GR8",
+    Address:         Address{"Av. Juárez", "123", "", "Centro", "Guadalajara", "Jalisco", "44100"},
+    MonthlyIncome:   15000,
+    Employment:      Employment{Employed, "Kueski", "Engineer", 24},
+    RequestedAmount: 3000,
+    Consent:         Consent{true, true, true, profileNow.Add(-time.Hour), "127.0.0.1"},
+    Attribution:     Attribution{Source: "partner", Campaign: "summer"},
+  }
+}
+
+func TestLeadProfileValidation(t *testing.T) {
+  assert.Nil(t, validProfile().validate(profileNow))
+
+  profile := validProfile()
+  profile.FirstName = " "
+  profile.BirthDate = "2003-06-16"
+  profile.Phone = "1234"
+  profile.Address.PostalCode = "4410"
+  profile.Employment.Employer = ""
+  profile.Consent.TermsAccepted = false
+  profile.Consent.AcceptedAt = profileNow.Add(time.Hour)
+
+  err := profile.validate(profileNow)
+
+  assert.Equal(t, LeadProfileError{
+    {"first_name", "is missing"},
+    {"birth_date", "is under the legal age"},
+    {"phone", "is not a valid Mexican phone number"},
+    {"address.postal_code", "is not 5 digits"},
+    {"employment.employer", "is missing"},
+    {"consent.terms_accepted", "must be accepted"},
+    {"consent.accepted_at", "is missing or in the future"},
+  }, err)
+  assert.Contains(t, err.Error(), "invalid lead profile: first_name: is missing; birth_date")
+
+  profile = validProfile()
+  profile.BirthDate = "2002-06-15"
+  profile.Employment = Employment{Status: Student}
+  profile.Rfc = ""
+  assert.Nil(t, profile.validate(profileNow))
+
+  profile.BirthDate = "1911-03-13"
+  assert.Nil(t, profile.validate(profileNow))
+
+  profile.Employment.Status = "astronaut"
+  profile.Rfc = "GODE561231GR9"
+  assert.Equal(t, LeadProfileError{{"rfc", "is not a valid RFC"}, {"employment.status", "is not a known status"}},
+    profile.validate(profileNow))
+}
+
+func TestLeadProfileJSON(t *testing.T) {
+  blob, err := json.Marshal(validProfile())
+  assert.Nil(t, err)
+
+  var fields map[string]interface{}
+  assert.Nil(t, json.Unmarshal(blob, &fields))
+  assert.Equal(t, "+523312345678", fields["phone"])
+  assert.Equal(t, "1990-05-15", fields["birth_date"])
+  assert.Equal(t, "44100", fields["address"].(map[string]interface{})["postal_code"])
+  assert.Equal(t, "employed", fields["employment"].(map[string]interface{})["status"])
+  assert.Equal(t, true, fields["consent"].(map[string]interface{})["terms_accepted"])
+}
+
+func TestEvaluateLeadProfile(t *testing.T) {
+  client := NewClient("http://kueski.com", "Key", "Secret")
+  profile := validProfile()
+  profile.Consent.AcceptedAt = time.Now().Add(-time.Hour)
+
+  _, err := client.DryRun("BADD110313HCMLNS06", "e@mail.com", profile)
+  assert.Nil(t, err)
+
+  _, err = client.DryRun("BADD110313HCMLNS06", "e@mail.com", &profile)
+  assert.Nil(t, err)
+
+  profile.Phone = ""
+  _, err = client.DryRun("BADD110313HCMLNS06", "e@mail.com", profile)
+  assert.Equal(t, util.FullDataFieldsError{Err: LeadProfileError{{"phone", "is not a valid Mexican phone number"}}}, err)
+  assert.ErrorIs(t, err, errors.InvalidFullDataFields)
+  assert.Equal(t, "InvalidFullDataFields: invalid lead profile: phone: is not a valid Mexican phone number", err.Error())
+  assert.Equal(t, "InvalidFullDataFields", errorName(err))
+
+  var missing *LeadProfile
+  _, err = client.DryRun("BADD110313HCMLNS06", "e@mail.com", missing)
+  assert.Equal(t, errors.MissingFullData, err)
+
+  _, err = client.DryRun("BADD110313HCMLNS06", "e@mail.com", map[string]interface{}{"anything": 1})
+  assert.Nil(t, err)
+}
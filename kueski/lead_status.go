@@ -0,0 +1,105 @@
+package kueski
+
+import (
+  "context"
+  "encoding/json"
+  "net/http"
+  "net/url"
+  "time"
+
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski/errors"
+)
+
+// leadStatusPath - Path to the lead status by request ID endpoint.
+const leadStatusPath string = "affiliates/lead-status"
+
+// Status - Lifecycle status of a lead at Kueski.
+type Status string
+
+// StatusInProgress - The lead is still being evaluated.
+// StatusApproved   - The loan was approved.
+// StatusRejected   - The loan was rejected.
+// StatusFunded     - The loan was disbursed.
+const (
+  StatusInProgress Status = "in_progress"
+  StatusApproved   Status = "approved"
+  StatusRejected   Status = "rejected"
+  StatusFunded     Status = "funded"
+)
+
+var validLeadStatus = map[Status]bool{StatusInProgress: true, StatusApproved: true, StatusRejected: true, StatusFunded: true}
+
+// Lead - Status of a lead, as returned by LeadStatus.
+// Reason - Why the lead was rejected, if it was.
+// FundedAt - Zero until the loan is funded.
+type Lead struct {
+  RequestID   string    `json:"request_id"`
+  Status      Status    `json:"status"`
+  Reason      string    `json:"reason,omitempty"`
+  EvaluatedAt time.Time `json:"evaluated_at"`
+  UpdatedAt   time.Time `json:"updated_at"`
+  FundedAt    time.Time `json:"funded_at,omitempty"`
+}
+
+var leadStatusErrors = map[string]error{
+  "Request not found":     errors.RequestIDNotFound,
+  "request_id is invalid": errors.InvalidRequestID,
+  "request_id is missing": errors.MissingRequestID,
+}
+
+// LeadStatus - Looks up what happened to a lead evaluated before.
+// requestID - Request ID returned by Evaluate.
+func (client *Client) LeadStatus(ctx context.Context, requestID string) (*Lead, error) {
+  if requestID == "" {
+    return nil, errors.MissingRequestID
+  }
+
+  ctx, end := client.startStage(ctx, StageLeadStatus)
+  lead, err := leadStatus(ctx, client, requestID)
+  end(err)
+
+  return lead, err
+}
+
+func leadStatus(ctx context.Context, client *Client, requestID string) (*Lead, error) {
+  response, err := client.call(ctx, http.MethodGet, leadStatusPath, url.Values{"request_id": {requestID}}, nil)
+
+  if err != nil {
+    return nil, err
+  }
+
+  responseBody, err := response.ReadBody()
+
+  if err != nil {
+    return nil, errors.InvalidLeadStatusResponseFormat
+  }
+
+  if response.StatusCode == 500 {
+    return nil, errors.InvalidLeadStatusResponseFormat
+  }
+
+  if response.StatusCode == 404 {
+    return nil, errors.RequestIDNotFound
+  }
+
+  if response.StatusCode == 400 {
+    return nil, resolveAPIError(responseBody, errors.InvalidLeadStatusResponseFormat, leadStatusErrors)
+  }
+
+  if response.StatusCode == 401 {
+    return nil, errors.ExpiredJWTToken
+  }
+
+  return resolveLeadStatusResponse(responseBody, requestID)
+}
+
+func resolveLeadStatusResponse(body []byte, requestID string) (*Lead, error) {
+  var lead Lead
+  responseErr := json.Unmarshal(body, &lead)
+
+  if responseErr != nil || !(lead.RequestID == requestID && validLeadStatus[lead.Status]) {
+    return nil, errors.InvalidLeadStatusResponseFormat
+  }
+
+  return &lead, nil
+}
@@ -0,0 +1,90 @@
+package kueski
+
+import (
+  "context"
+  "net/http"
+  "testing"
+
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski/errors"
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski/fakeserver"
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski/util"
+  "github.com/stretchr/testify/assert"
+)
+
+func TestLeadStatus(t *testing.T) {
+  server := fakeserver.New()
+  defer server.Close()
+
+  client := NewClient(server.URL, "Key", "Secret")
+  requestID, err := client.Evaluate("BADD110313HCMLNS06", "e@mail.com", map[string]string{"name": "Lead"})
+  assert.Nil(t, err)
+
+  lead, err := client.LeadStatus(context.Background(), requestID)
+
+  assert.Nil(t, err)
+  assert.Equal(t, requestID, lead.RequestID)
+  assert.Equal(t, StatusInProgress, lead.Status)
+  assert.False(t, lead.EvaluatedAt.IsZero())
+  assert.True(t, lead.FundedAt.IsZero())
+
+  server.SetStatus(requestID, "funded", "")
+  lead, err = client.LeadStatus(context.Background(), requestID)
+
+  assert.Nil(t, err)
+  assert.Equal(t, StatusFunded, lead.Status)
+  assert.False(t, lead.FundedAt.IsZero())
+
+  _, err = client.LeadStatus(context.Background(), "unknown")
+  assert.Equal(t, errors.RequestIDNotFound, err)
+
+  _, err = client.LeadStatus(context.Background(), "")
+  assert.Equal(t, errors.MissingRequestID, err)
+}
+
+func TestLeadStatusResponseErrors(t *testing.T) {
+  client := Client{}
+  client.jwtProvider = &fakeTokenProvider{true}
+  requestID := "0987654321"
+
+  responses := []*http.Response{
+    invalidHTTPResponse(),
+    buildHTTPResponse(500, ""),
+    buildHTTPResponse(404, ""),
+    buildHTTPResponse(400, `{ "error": "request_id is invalid" }`),
+    buildHTTPResponse(400, `{ "error": "something else" }`),
+    buildHTTPResponse(401, ``),
+    buildHTTPResponse(200, `{ "request_id": "other", "status": "funded" }`),
+    buildHTTPResponse(200, `{ "request_id": "0987654321", "status": "lost" }`),
+    buildHTTPResponse(200, `{ "request_id": "0987654321", "status": "rejected", "reason": "score", "updated_at": "2026-01-02T03:04:05Z" }`),
+  }
+
+  errors := []error{
+    errors.InvalidLeadStatusResponseFormat,
+    errors.InvalidLeadStatusResponseFormat,
+    errors.RequestIDNotFound,
+    errors.InvalidRequestID,
+    errors.ErrorNotIdentifiedFromAPI,
+    errors.ExpiredJWTToken,
+    errors.InvalidLeadStatusResponseFormat,
+    errors.InvalidLeadStatusResponseFormat,
+    nil,
+  }
+
+  for i, response := range responses {
+    client.SetRequester(util.RequesterFunc(func(request *util.Request) (*util.Response, error) {
+      assert.Equal(t, http.MethodGet, request.Method)
+      assert.Equal(t, "/affiliates/lead-status", request.URL)
+      assert.Equal(t, "0987654321", request.Query.Get("request_id"))
+      return util.WrapResponse(response), nil
+    }))
+
+    lead, err := leadStatus(context.Background(), &client, requestID)
+    assert.Equal(t, errors[i], err)
+
+    if err == nil {
+      assert.Equal(t, StatusRejected, lead.Status)
+      assert.Equal(t, "score", lead.Reason)
+      assert.Equal(t, 2026, lead.UpdatedAt.Year())
+    }
+  }
+}
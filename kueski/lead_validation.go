@@ -8,6 +8,10 @@ type curpValidator func(curp string) bool
 type emailValidator func(email string) bool
 type fullDataValidator func(fullData interface{}) error
 
+// Rule - Extra lead validation, run after the CURP, email and full data ones, e.g. a cross-check
+// between the CURP and the full data. Returns nil when the lead passes.
+type Rule func(curp, email string, fullData interface{}) error
+
 // Validator - Affiliate data validator: CURP, email and full data.
 type Validator struct {
   curp  curpValidator
@@ -33,3 +37,23 @@ func (validator *Validator) validate(curp, email string, fullData interface{}) e
 
   return validator.data(fullData)
 }
+
+// AddRules - Adds rules run by Evaluate and DryRun after the lead validation, in order.
+func (client *Client) AddRules(rules ...Rule) {
+  client.rules = append(client.rules, rules...)
+}
+
+// validate - Lead validation followed by the rules, failing with the first error.
+func (client *Client) validate(curp, email string, fullData interface{}) error {
+  if err := client.validator(curp, email, fullData); err != nil {
+    return err
+  }
+
+  for _, rule := range client.rules {
+    if err := rule(curp, email, fullData); err != nil {
+      return err
+    }
+  }
+
+  return nil
+}
@@ -1,9 +1,11 @@
 package kueski
 
 import (
+  "math/rand"
   "testing"
 
   "github.com/KueskiEngineering/go_affiliate-marketing/kueski/errors"
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski/util"
   "github.com/stretchr/testify/assert"
 )
 
@@ -26,3 +28,40 @@ func TestValidate(t *testing.T) {
     assert.Equal(t, results[i], validator.validate("", "", validator))
   }
 }
+
+func TestValidateRandomCurps(t *testing.T) {
+  validator := Validator{util.ValidateCurp, util.ValidateEmail, util.ValidateFullData}
+  random := rand.New(rand.NewSource(1))
+
+  for i := 0; i < 1000; i++ {
+    curp := util.RandomCurp(random)
+    assert.Nil(t, validator.validate(curp, "e@mail.com", map[string]string{}), curp)
+
+    broken := curp[:17] + string('0'+(curp[17]-'0'+1)%10)
+    assert.Equal(t, errors.InvalidCurp, validator.validate(broken, "e@mail.com", map[string]string{}), broken)
+  }
+}
+
+func TestRules(t *testing.T) {
+  calls := []string{}
+  client := NewClient("http://kueski.com", "Key", "Secret")
+  client.validator = func(curp, email string, fullData interface{}) error { return nil }
+  client.AddRules(func(curp, email string, fullData interface{}) error {
+    calls = append(calls, "first")
+    return nil
+  }, func(curp, email string, fullData interface{}) error {
+    calls = append(calls, "second")
+    return errors.PostalCodeStateMismatch
+  })
+
+  _, err := client.DryRun("CURP", "e@mail.com", map[string]string{})
+
+  assert.Equal(t, errors.PostalCodeStateMismatch, err)
+  assert.Equal(t, []string{"first", "second"}, calls)
+
+  client.validator = func(curp, email string, fullData interface{}) error { return errors.InvalidCurp }
+  _, err = client.Evaluate("CURP", "e@mail.com", map[string]string{})
+
+  assert.Equal(t, errors.InvalidCurp, err)
+  assert.Len(t, calls, 2)
+}
@@ -0,0 +1,47 @@
+package kueski
+
+import (
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski/limits"
+)
+
+// SetRateLimit - Limits the calls to the Kueski API, authentication included,
+// to rate calls per second with bursts of up to burst calls. Calls over the limit wait for their turn.
+// A rate of zero or less disables the limit.
+func (client *Client) SetRateLimit(rate float64, burst int) {
+  client.limiter = limits.NewTokenBucket(rate, burst)
+}
+
+// SetQuota - Enforces a daily lead quota on the client account (its API key).
+// Evaluations over the quota fail with QuotaExceeded before calling the API. Evaluations whose
+// lead evaluation request gets no response, and dry runs, do not count.
+func (client *Client) SetQuota(tracker *limits.QuotaTracker) {
+  client.quota = tracker
+}
+
+// QuotaStatus - Usage of today's quota of the client account. Remaining is -1 when there is no quota.
+func (client *Client) QuotaStatus() (limits.Quota, error) {
+  if client.quota == nil {
+    return limits.Quota{Account: client.apiKey, Remaining: -1}, nil
+  }
+
+  return client.quota.Status(client.apiKey)
+}
+
+// consumeQuota - Counts the lead, returning the day it was counted on for refundQuota.
+func (client *Client) consumeQuota() (string, error) {
+  if client.quota == nil {
+    return "", nil
+  }
+
+  return client.quota.Consume(client.apiKey)
+}
+
+func (client *Client) refundQuota(day string) {
+  if client.quota == nil {
+    return
+  }
+
+  if err := client.quota.Refund(client.apiKey, day); err != nil {
+    client.log().Error("kueski quota refund failed", "error", err)
+  }
+}
@@ -0,0 +1,289 @@
+package limits
+
+import (
+  "encoding/json"
+  "io/ioutil"
+  "os"
+  "path/filepath"
+  "strings"
+  "sync"
+  "time"
+
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski/errors"
+)
+
+// dayFormat - Layout of the day part of the quota counter keys.
+const dayFormat string = "2006-01-02"
+
+// QuotaStore - Persistence of the quota counters, keyed by account and day.
+// Decrement never takes a counter below zero.
+type QuotaStore interface {
+  Count(key string) (int, error)
+  Increment(key string) (int, error)
+  Decrement(key string) (int, error)
+}
+
+// Quota - Usage of the daily quota of an account.
+type Quota struct {
+  Account   string
+  Day       string
+  Limit     int
+  Used      int
+  Remaining int
+  ResetsAt  time.Time
+}
+
+// QuotaTracker - Daily lead quotas per account, reset at midnight of the configured time zone.
+type QuotaTracker struct {
+  limit    int
+  limits   map[string]int
+  location *time.Location
+  store    QuotaStore
+  now      func() time.Time
+  sync.Mutex
+}
+
+// NewQuotaTracker - QuotaTracker constructor.
+// limit - Default daily leads per account. Zero or less means unlimited.
+// location - Time zone where the day starts, UTC when nil.
+// store - Counters persistence, a MemoryStore when nil.
+func NewQuotaTracker(limit int, location *time.Location, store QuotaStore) *QuotaTracker {
+  if location == nil {
+    location = time.UTC
+  }
+
+  if store == nil {
+    store = NewMemoryStore()
+  }
+
+  return &QuotaTracker{limit: limit, limits: map[string]int{}, location: location, store: store, now: time.Now}
+}
+
+// SetLimit - Overrides the daily limit of an account.
+func (tracker *QuotaTracker) SetLimit(account string, limit int) {
+  tracker.Lock()
+  defer tracker.Unlock()
+  tracker.limits[account] = limit
+}
+
+// Consume - Counts one lead for the account, failing with QuotaExceeded when no quota is left.
+// Returns the day the lead was counted on, see Refund.
+func (tracker *QuotaTracker) Consume(account string) (string, error) {
+  tracker.Lock()
+  defer tracker.Unlock()
+
+  quota, err := tracker.status(account)
+
+  if err != nil {
+    return "", err
+  }
+
+  if quota.Limit > 0 && quota.Remaining <= 0 {
+    return "", errors.QuotaExceeded
+  }
+
+  _, err = tracker.store.Increment(key(account, quota.Day))
+  return quota.Day, err
+}
+
+// Refund - Gives back a lead counted by Consume, for leads that never reached the API.
+// day - Day returned by Consume, so a lead counted before midnight is not taken from the next day.
+func (tracker *QuotaTracker) Refund(account, day string) error {
+  tracker.Lock()
+  defer tracker.Unlock()
+
+  _, err := tracker.store.Decrement(key(account, day))
+  return err
+}
+
+// Status - Current usage of the daily quota of the account. Remaining is -1 when unlimited.
+func (tracker *QuotaTracker) Status(account string) (Quota, error) {
+  tracker.Lock()
+  defer tracker.Unlock()
+  return tracker.status(account)
+}
+
+func (tracker *QuotaTracker) status(account string) (Quota, error) {
+  now := tracker.now().In(tracker.location)
+  day := now.Format(dayFormat)
+  year, month, date := now.Date()
+
+  quota := Quota{Account: account, Day: day, Limit: tracker.limit, Remaining: -1}
+  quota.ResetsAt = time.Date(year, month, date+1, 0, 0, 0, 0, tracker.location)
+
+  if limit, ok := tracker.limits[account]; ok {
+    quota.Limit = limit
+  }
+
+  used, err := tracker.store.Count(key(account, day))
+
+  if err != nil {
+    return quota, err
+  }
+
+  quota.Used = used
+
+  if quota.Limit > 0 {
+    quota.Remaining = quota.Limit - used
+
+    if quota.Remaining < 0 {
+      quota.Remaining = 0
+    }
+  }
+
+  return quota, nil
+}
+
+func key(account, day string) string {
+  return account + "/" + day
+}
+
+// prune - Removes the counters of days before the day of key, they are never read again.
+func prune(counts map[string]int, key string) {
+  day := key[strings.LastIndex(key, "/")+1:]
+
+  for counted := range counts {
+    if counted[strings.LastIndex(counted, "/")+1:] < day {
+      delete(counts, counted)
+    }
+  }
+}
+
+// MemoryStore - In memory QuotaStore, counters are lost on restart.
+type MemoryStore struct {
+  counts map[string]int
+  sync.Mutex
+}
+
+// NewMemoryStore - MemoryStore constructor.
+func NewMemoryStore() *MemoryStore {
+  return &MemoryStore{counts: map[string]int{}}
+}
+
+// Count - Current value of the counter.
+func (store *MemoryStore) Count(key string) (int, error) {
+  store.Lock()
+  defer store.Unlock()
+  return store.counts[key], nil
+}
+
+// Increment - Adds one to the counter and returns the new value. Counters of past days are dropped.
+func (store *MemoryStore) Increment(key string) (int, error) {
+  store.Lock()
+  defer store.Unlock()
+  prune(store.counts, key)
+  store.counts[key]++
+  return store.counts[key], nil
+}
+
+// Decrement - Subtracts one from the counter, if positive, and returns the new value.
+func (store *MemoryStore) Decrement(key string) (int, error) {
+  store.Lock()
+  defer store.Unlock()
+
+  if store.counts[key] > 0 {
+    store.counts[key]--
+  }
+
+  return store.counts[key], nil
+}
+
+// FileStore - QuotaStore persisted as a JSON file, rewritten atomically on every change.
+type FileStore struct {
+  path   string
+  counts map[string]int
+  sync.Mutex
+}
+
+// NewFileStore - FileStore constructor, loading the counters from path when the file exists.
+func NewFileStore(path string) (*FileStore, error) {
+  store := &FileStore{path: path, counts: map[string]int{}}
+  blob, err := ioutil.ReadFile(path)
+
+  if os.IsNotExist(err) {
+    return store, nil
+  }
+
+  if err != nil {
+    return nil, err
+  }
+
+  if len(blob) > 0 {
+    if err := json.Unmarshal(blob, &store.counts); err != nil {
+      return nil, err
+    }
+  }
+
+  return store, nil
+}
+
+// Count - Current value of the counter.
+func (store *FileStore) Count(key string) (int, error) {
+  store.Lock()
+  defer store.Unlock()
+  return store.counts[key], nil
+}
+
+// Increment - Adds one to the counter, saves the file and returns the new value.
+// Counters of past days are dropped from the file.
+func (store *FileStore) Increment(key string) (int, error) {
+  store.Lock()
+  defer store.Unlock()
+
+  prune(store.counts, key)
+  store.counts[key]++
+
+  if err := store.save(); err != nil {
+    store.counts[key]--
+    return store.counts[key], err
+  }
+
+  return store.counts[key], nil
+}
+
+// Decrement - Subtracts one from the counter, if positive, saves the file and returns the new value.
+func (store *FileStore) Decrement(key string) (int, error) {
+  store.Lock()
+  defer store.Unlock()
+
+  if store.counts[key] == 0 {
+    return 0, nil
+  }
+
+  store.counts[key]--
+
+  if err := store.save(); err != nil {
+    store.counts[key]++
+    return store.counts[key], err
+  }
+
+  return store.counts[key], nil
+}
+
+func (store *FileStore) save() error {
+  blob, err := json.Marshal(store.counts)
+
+  if err != nil {
+    return err
+  }
+
+  temp, err := ioutil.TempFile(filepath.Dir(store.path), filepath.Base(store.path)+".tmp")
+
+  if err != nil {
+    return err
+  }
+
+  _, err = temp.Write(blob)
+  closeErr := temp.Close()
+
+  if err == nil {
+    err = closeErr
+  }
+
+  if err != nil {
+    os.Remove(temp.Name())
+    return err
+  }
+
+  return os.Rename(temp.Name(), store.path)
+}
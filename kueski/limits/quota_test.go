@@ -0,0 +1,118 @@
+package limits
+
+import (
+  "io/ioutil"
+  "os"
+  "path/filepath"
+  "testing"
+  "time"
+
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski/errors"
+  "github.com/stretchr/testify/assert"
+)
+
+func TestQuotaTracker(t *testing.T) {
+  location := time.FixedZone("CST", -6*60*60)
+  now := time.Date(2019, 1, 25, 23, 30, 0, 0, location)
+  tracker := NewQuotaTracker(2, location, nil)
+  tracker.now = func() time.Time { return now }
+  tracker.SetLimit("vip", 0)
+
+  day, err := tracker.Consume("key")
+  assert.Nil(t, err)
+  assert.Equal(t, "2019-01-25", day)
+  _, err = tracker.Consume("key")
+  assert.Nil(t, err)
+  _, err = tracker.Consume("key")
+  assert.Equal(t, errors.QuotaExceeded, err)
+
+  quota, err := tracker.Status("key")
+  assert.Nil(t, err)
+  assert.Equal(t, Quota{"key", "2019-01-25", 2, 2, 0, time.Date(2019, 1, 26, 0, 0, 0, 0, location)}, quota)
+
+  assert.Nil(t, tracker.Refund("key", day))
+  _, err = tracker.Consume("key")
+  assert.Nil(t, err)
+  assert.Nil(t, tracker.Refund("other", day))
+  quota, _ = tracker.Status("other")
+  assert.Equal(t, 0, quota.Used)
+
+  for i := 0; i < 5; i++ {
+    _, err = tracker.Consume("vip")
+    assert.Nil(t, err)
+  }
+
+  quota, _ = tracker.Status("vip")
+  assert.Equal(t, -1, quota.Remaining)
+  assert.Equal(t, 5, quota.Used)
+
+  now = now.Add(time.Hour)
+  quota, _ = tracker.Status("key")
+  assert.Equal(t, "2019-01-26", quota.Day)
+  assert.Equal(t, 2, quota.Remaining)
+  _, err = tracker.Consume("key")
+  assert.Nil(t, err)
+
+  assert.Nil(t, tracker.Refund("key", day))
+  quota, _ = tracker.Status("key")
+  assert.Equal(t, 1, quota.Used)
+}
+
+func TestMemoryStore(t *testing.T) {
+  store := NewMemoryStore()
+  store.Increment("key/2019-01-25")
+  store.Increment("other/2019-01-25")
+
+  count, _ := store.Decrement("key/2019-01-25")
+  assert.Equal(t, 0, count)
+  count, _ = store.Decrement("key/2019-01-25")
+  assert.Equal(t, 0, count)
+
+  store.Increment("key/2019-01-26")
+  assert.Equal(t, map[string]int{"key/2019-01-26": 1}, store.counts)
+}
+
+func TestFileStore(t *testing.T) {
+  dir, _ := ioutil.TempDir("", "quota")
+  defer os.RemoveAll(dir)
+  path := filepath.Join(dir, "quota.json")
+
+  store, err := NewFileStore(path)
+  assert.Nil(t, err)
+
+  count, err := store.Increment("key/2019-01-25")
+  assert.Nil(t, err)
+  assert.Equal(t, 1, count)
+  store.Increment("key/2019-01-25")
+
+  reloaded, err := NewFileStore(path)
+  assert.Nil(t, err)
+  count, _ = reloaded.Count("key/2019-01-25")
+  assert.Equal(t, 2, count)
+
+  count, err = reloaded.Decrement("key/2019-01-25")
+  assert.Nil(t, err)
+  assert.Equal(t, 1, count)
+
+  reloaded.Increment("key/2019-01-26")
+  reloaded, _ = NewFileStore(path)
+  count, _ = reloaded.Count("key/2019-01-25")
+  assert.Equal(t, 0, count)
+  assert.Equal(t, map[string]int{"key/2019-01-26": 1}, reloaded.counts)
+
+  count, err = reloaded.Decrement("key/2019-01-25")
+  assert.Nil(t, err)
+  assert.Equal(t, 0, count)
+
+  ioutil.WriteFile(path, []byte("{"), 0644)
+  _, err = NewFileStore(path)
+  assert.NotNil(t, err)
+
+  _, err = NewFileStore(dir)
+  assert.NotNil(t, err)
+
+  broken, _ := NewFileStore(filepath.Join(dir, "missing", "quota.json"))
+  count, err = broken.Increment("key")
+  assert.NotNil(t, err)
+  assert.Equal(t, 0, count)
+}
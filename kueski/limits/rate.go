@@ -0,0 +1,120 @@
+package limits
+
+import (
+  "context"
+  "net/http"
+  "sync"
+  "time"
+
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski/util"
+)
+
+// TokenBucket - Token bucket rate limiter: up to burst requests at once, refilled at rate per second.
+type TokenBucket struct {
+  rate   float64
+  burst  float64
+  tokens float64
+  last   time.Time
+  now    func() time.Time
+  sync.Mutex
+}
+
+// NewTokenBucket - TokenBucket constructor. The bucket starts full.
+// rate - Tokens added per second. Zero or less disables the limit: every request is let through.
+// burst - Bucket capacity, at least one.
+func NewTokenBucket(rate float64, burst int) *TokenBucket {
+  if burst < 1 {
+    burst = 1
+  }
+
+  bucket := &TokenBucket{rate: rate, burst: float64(burst), tokens: float64(burst), now: time.Now}
+  bucket.last = bucket.now()
+  return bucket
+}
+
+// Allow - Takes a token if one is available, without waiting.
+func (bucket *TokenBucket) Allow() bool {
+  bucket.Lock()
+  defer bucket.Unlock()
+
+  if bucket.rate <= 0 {
+    return true
+  }
+
+  bucket.refill()
+
+  if bucket.tokens < 1 {
+    return false
+  }
+
+  bucket.tokens--
+  return true
+}
+
+// Wait - Takes a token, blocking until one is available or ctx is done.
+func (bucket *TokenBucket) Wait(ctx context.Context) error {
+  delay := bucket.reserve()
+
+  if delay == 0 {
+    return nil
+  }
+
+  timer := time.NewTimer(delay)
+  defer timer.Stop()
+
+  select {
+  case <-ctx.Done():
+    bucket.cancel()
+    return ctx.Err()
+  case <-timer.C:
+    return nil
+  }
+}
+
+// Middleware - Transport middleware waiting for a token before every request.
+func (bucket *TokenBucket) Middleware() util.Middleware {
+  return func(next http.RoundTripper) http.RoundTripper {
+    return util.RoundTripperFunc(func(request *http.Request) (*http.Response, error) {
+      if err := bucket.Wait(request.Context()); err != nil {
+        return nil, err
+      }
+
+      return next.RoundTrip(request)
+    })
+  }
+}
+
+// reserve - Takes a token, possibly going into debt, and returns how long to wait for it.
+func (bucket *TokenBucket) reserve() time.Duration {
+  bucket.Lock()
+  defer bucket.Unlock()
+
+  if bucket.rate <= 0 {
+    return 0
+  }
+
+  bucket.refill()
+  bucket.tokens--
+
+  if bucket.tokens >= 0 {
+    return 0
+  }
+
+  return time.Duration(-bucket.tokens / bucket.rate * float64(time.Second))
+}
+
+func (bucket *TokenBucket) refill() {
+  now := bucket.now()
+  bucket.tokens += now.Sub(bucket.last).Seconds() * bucket.rate
+  bucket.last = now
+
+  if bucket.tokens > bucket.burst {
+    bucket.tokens = bucket.burst
+  }
+}
+
+func (bucket *TokenBucket) cancel() {
+  bucket.Lock()
+  defer bucket.Unlock()
+  bucket.tokens++
+}
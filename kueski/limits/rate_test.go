@@ -0,0 +1,88 @@
+package limits
+
+import (
+  "context"
+  "net/http"
+  "testing"
+  "time"
+
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski/util"
+  "github.com/stretchr/testify/assert"
+)
+
+func TestTokenBucketAllow(t *testing.T) {
+  now := time.Now()
+  bucket := NewTokenBucket(2, 3)
+  bucket.now = func() time.Time { return now }
+  bucket.last = now
+
+  assert.True(t, bucket.Allow())
+  assert.True(t, bucket.Allow())
+  assert.True(t, bucket.Allow())
+  assert.False(t, bucket.Allow())
+
+  now = now.Add(500 * time.Millisecond)
+  assert.True(t, bucket.Allow())
+  assert.False(t, bucket.Allow())
+
+  now = now.Add(time.Hour)
+  assert.True(t, bucket.Allow())
+  assert.True(t, bucket.Allow())
+  assert.True(t, bucket.Allow())
+  assert.False(t, bucket.Allow())
+}
+
+func TestTokenBucketWait(t *testing.T) {
+  bucket := NewTokenBucket(100, 1)
+
+  start := time.Now()
+  assert.Nil(t, bucket.Wait(context.Background()))
+  assert.Nil(t, bucket.Wait(context.Background()))
+  assert.True(t, time.Since(start) >= 5*time.Millisecond)
+
+  slow := NewTokenBucket(0.001, 1)
+  slow.Allow()
+  ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+  defer cancel()
+
+  assert.Equal(t, context.DeadlineExceeded, slow.Wait(ctx))
+  assert.InDelta(t, 0, slow.tokens, 0.01)
+}
+
+func TestTokenBucketDisabled(t *testing.T) {
+  for _, rate := range []float64{0, -1} {
+    bucket := NewTokenBucket(rate, 1)
+    ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+
+    for i := 0; i < 3; i++ {
+      assert.True(t, bucket.Allow())
+      assert.Nil(t, bucket.Wait(ctx))
+    }
+
+    cancel()
+  }
+}
+
+func TestTokenBucketMiddleware(t *testing.T) {
+  calls := 0
+  base := util.RoundTripperFunc(func(request *http.Request) (*http.Response, error) {
+    calls++
+    return &http.Response{StatusCode: 201}, nil
+  })
+
+  bucket := NewTokenBucket(0.001, 1)
+  transport := util.Chain(base, bucket.Middleware())
+
+  request, _ := http.NewRequest("POST", "http://kueski.com", nil)
+  response, err := transport.RoundTrip(request)
+  assert.Nil(t, err)
+  assert.Equal(t, 201, response.StatusCode)
+
+  ctx, cancel := context.WithCancel(context.Background())
+  cancel()
+  response, err = transport.RoundTrip(request.WithContext(ctx))
+
+  assert.Nil(t, response)
+  assert.Equal(t, context.Canceled, err)
+  assert.Equal(t, 1, calls)
+}
@@ -0,0 +1,95 @@
+package kueski
+
+import (
+  "context"
+  "fmt"
+  "net/http"
+  "testing"
+  "time"
+
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski/errors"
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski/limits"
+  "github.com/stretchr/testify/assert"
+)
+
+func TestQuota(t *testing.T) {
+  evaluations := 0
+  client := NewClient("http://kueski.com", "Key", "Secret")
+  client.validator = func(curp, email string, fullData interface{}) error { return nil }
+  client.evaluator = func(ctx context.Context, client *Client, curp, email string) (string, error) {
+    evaluations++
+    return requestID, nil
+  }
+  client.dataHandler = func(ctx context.Context, client *Client, jsonData interface{}, requestID string) error { return nil }
+
+  quota, err := client.QuotaStatus()
+  assert.Nil(t, err)
+  assert.Equal(t, -1, quota.Remaining)
+
+  client.SetQuota(limits.NewQuotaTracker(1, nil, nil))
+  _, err = client.Evaluate(curp, email, "data")
+  assert.Nil(t, err)
+
+  returned, err := client.Evaluate(curp, email, "data")
+  assert.Equal(t, "", returned)
+  assert.Equal(t, errors.QuotaExceeded, err)
+  assert.Equal(t, 1, evaluations)
+
+  quota, err = client.QuotaStatus()
+  assert.Nil(t, err)
+  assert.Equal(t, "Key", quota.Account)
+  assert.Equal(t, 1, quota.Used)
+  assert.Equal(t, 0, quota.Remaining)
+}
+
+func TestQuotaOnlyCountsSentLeads(t *testing.T) {
+  var response *http.Response
+  client := NewClient("http://kueski.com", "Key", "Secret")
+  client.jwtProvider = &fakeTokenProvider{true}
+  client.validator = func(curp, email string, fullData interface{}) error { return nil }
+  client.requester = func(url string, headers map[string]string, body []byte) (*http.Response, error) {
+    if response == nil {
+      return nil, errors.UnableToMakeConnection
+    }
+
+    return response, nil
+  }
+  client.SetQuota(limits.NewQuotaTracker(1, nil, nil))
+
+  _, err := client.Evaluate(curp, email, "data")
+  assert.Equal(t, errors.UnableToMakeConnection, err)
+
+  _, err = client.DryRun(curp, email, "data")
+  assert.Nil(t, err)
+
+  quota, _ := client.QuotaStatus()
+  assert.Equal(t, 0, quota.Used)
+
+  response = buildHTTPResponse(201, fmt.Sprintf(`{ "curp": "%s", "email": "%s", "request_id": "%s", "status": "duplicated" }`, curp, email, requestID))
+  _, err = client.Evaluate(curp, email, "data")
+  assert.Equal(t, errors.DuplicatedLead, err)
+
+  quota, _ = client.QuotaStatus()
+  assert.Equal(t, 1, quota.Used)
+}
+
+func TestRateLimit(t *testing.T) {
+  calls := 0
+  client := Client{}
+  client.jwtProvider = &fakeTokenProvider{true}
+  client.requester = func(url string, headers map[string]string, body []byte) (*http.Response, error) {
+    calls++
+    return buildHTTPResponse(201, ""), nil
+  }
+  client.SetRateLimit(0.001, 1)
+
+  _, err := client.makeRequest(context.Background(), "path", []byte(""))
+  assert.Nil(t, err)
+
+  ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+  defer cancel()
+  _, err = client.makeRequest(ctx, "path", []byte(""))
+
+  assert.Equal(t, context.DeadlineExceeded, err)
+  assert.Equal(t, 1, calls)
+}
@@ -0,0 +1,227 @@
+package logging
+
+import (
+  "crypto/sha256"
+  "encoding/hex"
+  "fmt"
+  "regexp"
+  "strings"
+)
+
+// Logger - Structured logger taking alternating key/value pairs after the message.
+// *slog.Logger satisfies it, as do thin adapters over zap, zerolog or logrus.
+type Logger interface {
+  Debug(msg string, keyvals ...interface{})
+  Info(msg string, keyvals ...interface{})
+  Warn(msg string, keyvals ...interface{})
+  Error(msg string, keyvals ...interface{})
+}
+
+// NopLogger - Logger that discards everything.
+type NopLogger struct{}
+
+// Debug - Discards the entry.
+func (NopLogger) Debug(msg string, keyvals ...interface{}) {}
+
+// Info - Discards the entry.
+func (NopLogger) Info(msg string, keyvals ...interface{}) {}
+
+// Warn - Discards the entry.
+func (NopLogger) Warn(msg string, keyvals ...interface{}) {}
+
+// Error - Discards the entry.
+func (NopLogger) Error(msg string, keyvals ...interface{}) {}
+
+// Redacted - Placeholder for values that cannot be logged at all.
+const Redacted string = "[REDACTED]"
+
+// secretKeys - Keys whose key/value pair is dropped from the entry.
+var secretKeys = map[string]bool{
+  "authorization": true,
+  "secret":        true,
+  "secret_key":    true,
+  "secretkey":     true,
+  "api_key":       true,
+  "apikey":        true,
+  "token":         true,
+  "jwt":           true,
+  "password":      true,
+  "full_data":     true,
+  "fulldata":      true,
+}
+
+var (
+  jwtRegex        = regexp.MustCompile(`eyJ[A-Za-z0-9_-]*\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`)
+  credentialRegex = regexp.MustCompile(`(?i)\b(APIAuth|Bearer)\s+[^\s,;]+`)
+  curpRegex       = regexp.MustCompile(`\b[A-Z]{4}\d{6}[HMX][A-Z]{5}[0-9A-Z]\d\b`)
+  emailRegex      = regexp.MustCompile(`[a-zA-Z0-9.!#$%&'*+/=?^_{|}~-]+@[a-zA-Z0-9-]+(?:\.[a-zA-Z0-9-]+)+`)
+)
+
+// RedactOptions - Configuration of the redaction layer.
+// HashKeys - Keys whose values are replaced by a salted hash instead of being masked,
+// so entries about the same lead can be correlated without exposing it (e.g. "curp", "email").
+// Salt - Salt for the hashes.
+type RedactOptions struct {
+  HashKeys []string
+  Salt     string
+}
+
+// Redactor - Logger decorator that masks CURPs and emails, and drops secrets and JWTs.
+type Redactor struct {
+  next     Logger
+  hashKeys map[string]bool
+  salt     string
+}
+
+// NewRedactor - Wraps next with the redaction layer.
+func NewRedactor(next Logger, options RedactOptions) *Redactor {
+  redactor := &Redactor{next, map[string]bool{}, options.Salt}
+
+  for _, key := range options.HashKeys {
+    redactor.hashKeys[strings.ToLower(key)] = true
+  }
+
+  return redactor
+}
+
+// Redact - Wraps logger with the default redaction layer, unless it already is a Redactor.
+// A nil logger becomes a NopLogger.
+func Redact(logger Logger) Logger {
+  if logger == nil {
+    return NopLogger{}
+  }
+
+  if _, ok := logger.(*Redactor); ok {
+    return logger
+  }
+
+  return NewRedactor(logger, RedactOptions{})
+}
+
+// Debug - Logs a redacted entry at debug level.
+func (redactor *Redactor) Debug(msg string, keyvals ...interface{}) {
+  redactor.next.Debug(Scrub(msg), redactor.Fields(keyvals...)...)
+}
+
+// Info - Logs a redacted entry at info level.
+func (redactor *Redactor) Info(msg string, keyvals ...interface{}) {
+  redactor.next.Info(Scrub(msg), redactor.Fields(keyvals...)...)
+}
+
+// Warn - Logs a redacted entry at warn level.
+func (redactor *Redactor) Warn(msg string, keyvals ...interface{}) {
+  redactor.next.Warn(Scrub(msg), redactor.Fields(keyvals...)...)
+}
+
+// Error - Logs a redacted entry at error level.
+func (redactor *Redactor) Error(msg string, keyvals ...interface{}) {
+  redactor.next.Error(Scrub(msg), redactor.Fields(keyvals...)...)
+}
+
+// Fields - Applies the redaction rules to a list of key/value pairs.
+func (redactor *Redactor) Fields(keyvals ...interface{}) []interface{} {
+  fields := []interface{}{}
+
+  for i := 0; i < len(keyvals); i += 2 {
+    key, isString := keyvals[i].(string)
+
+    if i+1 == len(keyvals) {
+      fields = append(fields, redactor.value("", keyvals[i]))
+      break
+    }
+
+    if !isString {
+      fields = append(fields, keyvals[i], redactor.value("", keyvals[i+1]))
+      continue
+    }
+
+    lower := strings.ToLower(key)
+
+    if secretKeys[lower] {
+      continue
+    }
+
+    fields = append(fields, key, redactor.value(lower, keyvals[i+1]))
+  }
+
+  return fields
+}
+
+func (redactor *Redactor) value(key string, value interface{}) interface{} {
+  if redactor.hashKeys[key] {
+    return Hash(fmt.Sprint(value), redactor.salt)
+  }
+
+  switch typed := value.(type) {
+  case string:
+    return redactor.stringValue(key, typed)
+  case error:
+    return Scrub(typed.Error())
+  case map[string]string:
+    return redactor.headers(typed)
+  case fmt.Stringer:
+    return Scrub(typed.String())
+  }
+
+  return value
+}
+
+func (redactor *Redactor) stringValue(key, value string) string {
+  switch key {
+  case "curp":
+    return MaskCurp(value)
+  case "email":
+    return MaskEmail(value)
+  }
+
+  return Scrub(value)
+}
+
+func (redactor *Redactor) headers(headers map[string]string) map[string]string {
+  redacted := map[string]string{}
+
+  for header, value := range headers {
+    if secretKeys[strings.ToLower(header)] {
+      redacted[header] = Redacted
+      continue
+    }
+
+    redacted[header] = Scrub(value)
+  }
+
+  return redacted
+}
+
+// Scrub - Masks CURPs and emails, and removes JWTs and credentials, found in free text.
+func Scrub(text string) string {
+  text = credentialRegex.ReplaceAllString(text, Redacted)
+  text = jwtRegex.ReplaceAllString(text, Redacted)
+  text = curpRegex.ReplaceAllStringFunc(text, MaskCurp)
+  return emailRegex.ReplaceAllStringFunc(text, MaskEmail)
+}
+
+// MaskCurp - Keeps the first four characters of a CURP (name initials) and masks the rest.
+func MaskCurp(curp string) string {
+  if len(curp) <= 4 {
+    return strings.Repeat("*", len(curp))
+  }
+
+  return curp[:4] + strings.Repeat("*", len(curp)-4)
+}
+
+// MaskEmail - Keeps the first character of the local part and the domain of an email.
+func MaskEmail(email string) string {
+  at := strings.LastIndex(email, "@")
+
+  if at < 1 {
+    return strings.Repeat("*", len(email))
+  }
+
+  return email[:1] + "***" + email[at:]
+}
+
+// Hash - Salted SHA-256 of value, truncated, to correlate identifiers without logging them.
+func Hash(value, salt string) string {
+  sum := sha256.Sum256([]byte(salt + value))
+  return "sha256:" + hex.EncodeToString(sum[:8])
+}
@@ -0,0 +1,167 @@
+package kueski
+
+import (
+  "net/http"
+  "net/url"
+  "path"
+  "strconv"
+  "sync"
+  "time"
+
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski/breaker"
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski/errors"
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski/metrics"
+)
+
+// Metrics - Prometheus metrics collected from the Client lifecycle hooks.
+type Metrics struct {
+  registry        *metrics.Registry
+  requests        *metrics.Counter
+  requestLatency  *metrics.Histogram
+  phaseLatency    *metrics.Histogram
+  failures        *metrics.Counter
+  leads           *metrics.Counter
+  tokenRefreshes  *metrics.Counter
+  tokenFailures   *metrics.Counter
+  tokenExpiration time.Time
+  transitions     *metrics.Counter
+  circuitStates   *metrics.Gauge
+  sync.Mutex
+}
+
+// leadStatuses - Lead outcome label for each lead evaluation result.
+var leadStatuses = map[error]string{
+  nil:                   "approved",
+  errors.DuplicatedLead: "duplicated",
+  errors.ExistingLead:   "existing",
+}
+
+// NewMetrics - Metrics constructor. Use Client.EnableMetrics to attach it to a client.
+func NewMetrics() *Metrics {
+  registry := metrics.NewRegistry()
+  collector := &Metrics{registry: registry}
+
+  collector.requests = registry.NewCounter("kueski_requests_total",
+    "Requests made to the Kueski API by endpoint and status code.", "endpoint", "code")
+  collector.requestLatency = registry.NewHistogram("kueski_request_duration_seconds",
+    "Latency of the requests made to the Kueski API by endpoint.", nil, "endpoint")
+  collector.phaseLatency = registry.NewHistogram("kueski_phase_duration_seconds",
+    "Latency of each Evaluate phase.", nil, "phase")
+  collector.failures = registry.NewCounter("kueski_errors_total",
+    "Errors by Evaluate phase and ResponseError name.", "phase", "error")
+  collector.leads = registry.NewCounter("kueski_leads_total",
+    "Evaluated leads by resulting status.", "status")
+  collector.tokenRefreshes = registry.NewCounter("kueski_token_refreshes_total",
+    "JWT renewals.")
+  collector.tokenFailures = registry.NewCounter("kueski_token_refresh_failures_total",
+    "Failed JWT renewals.")
+  registry.NewGaugeFunc("kueski_token_expiry_seconds",
+    "Seconds until the current JWT expires.", collector.tokenTimeToExpiry)
+  collector.transitions = registry.NewCounter("kueski_circuit_transitions_total",
+    "Circuit breaker transitions by host and resulting state.", "host", "state")
+  collector.circuitStates = registry.NewGauge("kueski_circuit_state",
+    "Circuit breaker state by host: 0 closed, 1 half-open, 2 open.", "host")
+
+  return collector
+}
+
+// EnableMetrics - Attaches a new metrics collector to the client and returns it.
+func (client *Client) EnableMetrics() *Metrics {
+  collector := NewMetrics()
+  client.AddHooks(collector.Hooks())
+  return collector
+}
+
+// Registry - Underlying registry, to add application metrics to the same endpoint.
+func (collector *Metrics) Registry() *metrics.Registry {
+  return collector.registry
+}
+
+// ServeHTTP - Exposes the metrics in the Prometheus text exposition format.
+func (collector *Metrics) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+  collector.registry.ServeHTTP(writer, request)
+}
+
+// Hooks - Lifecycle hooks feeding the collector.
+func (collector *Metrics) Hooks() Hooks {
+  return Hooks{
+    AfterResponse:       collector.observeResponse,
+    TokenRefreshed:      collector.observeToken,
+    LeadEvaluated:       collector.observeLead,
+    Failed:              collector.observeFailure,
+    PhaseCompleted:      collector.observePhase,
+    CircuitStateChanged: collector.observeCircuit,
+  }
+}
+
+func (collector *Metrics) observeResponse(method, rawURL string, statusCode int, elapsed time.Duration, err error) {
+  endpoint := endpointName(rawURL)
+  code := strconv.Itoa(statusCode)
+
+  if err != nil {
+    code = "error"
+  }
+
+  collector.requests.Inc(endpoint, code)
+  collector.requestLatency.Observe(elapsed.Seconds(), endpoint)
+}
+
+func (collector *Metrics) observeToken(expiration time.Time) {
+  collector.tokenRefreshes.Inc()
+
+  collector.Lock()
+  defer collector.Unlock()
+  collector.tokenExpiration = expiration
+}
+
+func (collector *Metrics) observeLead(requestID string, err error) {
+  if status, ok := leadStatuses[err]; ok {
+    collector.leads.Inc(status)
+  }
+}
+
+func (collector *Metrics) observeFailure(stage string, err error) {
+  collector.failures.Inc(stage, errorName(err))
+
+  if stage == StageAuthentication {
+    collector.tokenFailures.Inc()
+  }
+}
+
+func (collector *Metrics) observePhase(stage string, elapsed time.Duration, err error) {
+  collector.phaseLatency.Observe(elapsed.Seconds(), stage)
+}
+
+func (collector *Metrics) observeCircuit(host string, from, to breaker.State) {
+  collector.transitions.Inc(host, to.String())
+  collector.circuitStates.Set(float64(to), host)
+}
+
+func (collector *Metrics) tokenTimeToExpiry() float64 {
+  collector.Lock()
+  defer collector.Unlock()
+
+  if collector.tokenExpiration.IsZero() {
+    return 0
+  }
+
+  return time.Until(collector.tokenExpiration).Seconds()
+}
+
+func endpointName(rawURL string) string {
+  parsed, err := url.Parse(rawURL)
+
+  if err != nil || parsed.Path == "" {
+    return "unknown"
+  }
+
+  return path.Base(parsed.Path)
+}
+
+func errorName(err error) string {
+  if responseError, ok := responseErrorOf(err); ok {
+    return responseError.String()
+  }
+
+  return "Unknown"
+}
@@ -0,0 +1,358 @@
+package metrics
+
+import (
+  "bytes"
+  "fmt"
+  "io"
+  "math"
+  "net/http"
+  "sort"
+  "strconv"
+  "strings"
+  "sync"
+)
+
+// ContentType - Content type of the Prometheus text exposition format.
+const ContentType string = "text/plain; version=0.0.4; charset=utf-8"
+
+// DefaultBuckets - Default latency buckets, in seconds.
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+type collector interface {
+  write(buffer *bytes.Buffer)
+}
+
+// Registry - Set of metrics exposed together in the Prometheus text format.
+type Registry struct {
+  collectors []collector
+  sync.Mutex
+}
+
+// NewRegistry - Registry constructor.
+func NewRegistry() *Registry {
+  return new(Registry)
+}
+
+// NewCounter - Registers a counter with the given label names.
+func (registry *Registry) NewCounter(name, help string, labels ...string) *Counter {
+  counter := &Counter{family{name, help, labels}, map[string]*counterValue{}, sync.Mutex{}}
+  registry.register(counter)
+  return counter
+}
+
+// NewHistogram - Registers a histogram with the given upper bounds and label names.
+// buckets - Upper bounds in increasing order, DefaultBuckets is used when nil.
+func (registry *Registry) NewHistogram(name, help string, buckets []float64, labels ...string) *Histogram {
+  if buckets == nil {
+    buckets = DefaultBuckets
+  }
+
+  histogram := &Histogram{family{name, help, labels}, buckets, map[string]*histogramValue{}, sync.Mutex{}}
+  registry.register(histogram)
+  return histogram
+}
+
+// NewGauge - Registers a gauge with the given label names.
+func (registry *Registry) NewGauge(name, help string, labels ...string) *Gauge {
+  gauge := &Gauge{family{name, help, labels}, map[string]*counterValue{}, sync.Mutex{}}
+  registry.register(gauge)
+  return gauge
+}
+
+// NewGaugeFunc - Registers a gauge whose value is computed on every scrape.
+func (registry *Registry) NewGaugeFunc(name, help string, value func() float64) {
+  registry.register(&gaugeFunc{family{name, help, nil}, value})
+}
+
+// WriteTo - Writes every registered metric in the Prometheus text exposition format.
+func (registry *Registry) WriteTo(writer io.Writer) (int64, error) {
+  registry.Lock()
+  collectors := append([]collector{}, registry.collectors...)
+  registry.Unlock()
+
+  var buffer bytes.Buffer
+
+  for _, c := range collectors {
+    c.write(&buffer)
+  }
+
+  return buffer.WriteTo(writer)
+}
+
+// ServeHTTP - Exposes the registry as a Prometheus scrape endpoint.
+func (registry *Registry) ServeHTTP(writer http.ResponseWriter, _ *http.Request) {
+  writer.Header().Set("Content-Type", ContentType)
+  registry.WriteTo(writer)
+}
+
+func (registry *Registry) register(c collector) {
+  registry.Lock()
+  defer registry.Unlock()
+  registry.collectors = append(registry.collectors, c)
+}
+
+type family struct {
+  name   string
+  help   string
+  labels []string
+}
+
+func (f *family) header(buffer *bytes.Buffer, kind string) {
+  fmt.Fprintf(buffer, "# HELP %s %s\n", f.name, escapeHelp(f.help))
+  fmt.Fprintf(buffer, "# TYPE %s %s\n", f.name, kind)
+}
+
+func (f *family) key(values []string) string {
+  if len(values) != len(f.labels) {
+    panic(fmt.Sprintf("metrics: %s expects %d label values, got %d", f.name, len(f.labels), len(values)))
+  }
+
+  return strings.Join(values, "\xff")
+}
+
+func (f *family) labelPairs(values []string, extra ...string) string {
+  pairs := []string{}
+
+  for i, label := range f.labels {
+    pairs = append(pairs, fmt.Sprintf(`%s="%s"`, label, escapeLabel(values[i])))
+  }
+
+  for i := 0; i+1 < len(extra); i += 2 {
+    pairs = append(pairs, fmt.Sprintf(`%s="%s"`, extra[i], escapeLabel(extra[i+1])))
+  }
+
+  if len(pairs) == 0 {
+    return ""
+  }
+
+  return "{" + strings.Join(pairs, ",") + "}"
+}
+
+// Counter - Monotonic counter partitioned by label values.
+type Counter struct {
+  family
+  values map[string]*counterValue
+  sync.Mutex
+}
+
+type counterValue struct {
+  labels []string
+  value  float64
+}
+
+// Inc - Increments the counter for the given label values by one.
+func (counter *Counter) Inc(labelValues ...string) {
+  counter.Add(1, labelValues...)
+}
+
+// Add - Increments the counter for the given label values. Negative deltas are ignored.
+func (counter *Counter) Add(delta float64, labelValues ...string) {
+  if delta < 0 {
+    return
+  }
+
+  key := counter.key(labelValues)
+
+  counter.Lock()
+  defer counter.Unlock()
+
+  value, ok := counter.values[key]
+
+  if !ok {
+    value = &counterValue{append([]string{}, labelValues...), 0}
+    counter.values[key] = value
+  }
+
+  value.value += delta
+}
+
+// Value - Current value for the given label values.
+func (counter *Counter) Value(labelValues ...string) float64 {
+  key := counter.key(labelValues)
+
+  counter.Lock()
+  defer counter.Unlock()
+
+  if value, ok := counter.values[key]; ok {
+    return value.value
+  }
+
+  return 0
+}
+
+func (counter *Counter) write(buffer *bytes.Buffer) {
+  counter.Lock()
+  defer counter.Unlock()
+
+  counter.header(buffer, "counter")
+
+  for _, key := range sortedKeys(counter.values) {
+    value := counter.values[key]
+    fmt.Fprintf(buffer, "%s%s %s\n", counter.name, counter.labelPairs(value.labels), formatFloat(value.value))
+  }
+}
+
+// Histogram - Cumulative histogram partitioned by label values.
+type Histogram struct {
+  family
+  buckets []float64
+  values  map[string]*histogramValue
+  sync.Mutex
+}
+
+type histogramValue struct {
+  labels []string
+  counts []uint64
+  count  uint64
+  sum    float64
+}
+
+// Observe - Records a sample for the given label values.
+func (histogram *Histogram) Observe(sample float64, labelValues ...string) {
+  key := histogram.key(labelValues)
+
+  histogram.Lock()
+  defer histogram.Unlock()
+
+  value, ok := histogram.values[key]
+
+  if !ok {
+    value = &histogramValue{append([]string{}, labelValues...), make([]uint64, len(histogram.buckets)), 0, 0}
+    histogram.values[key] = value
+  }
+
+  for i, bound := range histogram.buckets {
+    if sample <= bound {
+      value.counts[i]++
+    }
+  }
+
+  value.count++
+  value.sum += sample
+}
+
+// Count - Number of samples recorded for the given label values.
+func (histogram *Histogram) Count(labelValues ...string) uint64 {
+  key := histogram.key(labelValues)
+
+  histogram.Lock()
+  defer histogram.Unlock()
+
+  if value, ok := histogram.values[key]; ok {
+    return value.count
+  }
+
+  return 0
+}
+
+func (histogram *Histogram) write(buffer *bytes.Buffer) {
+  histogram.Lock()
+  defer histogram.Unlock()
+
+  histogram.header(buffer, "histogram")
+
+  for _, key := range sortedKeys(histogram.values) {
+    value := histogram.values[key]
+
+    for i, bound := range histogram.buckets {
+      labels := histogram.labelPairs(value.labels, "le", formatFloat(bound))
+      fmt.Fprintf(buffer, "%s_bucket%s %d\n", histogram.name, labels, value.counts[i])
+    }
+
+    fmt.Fprintf(buffer, "%s_bucket%s %d\n", histogram.name, histogram.labelPairs(value.labels, "le", "+Inf"), value.count)
+    fmt.Fprintf(buffer, "%s_sum%s %s\n", histogram.name, histogram.labelPairs(value.labels), formatFloat(value.sum))
+    fmt.Fprintf(buffer, "%s_count%s %d\n", histogram.name, histogram.labelPairs(value.labels), value.count)
+  }
+}
+
+// Gauge - Value that can go up and down, partitioned by label values.
+type Gauge struct {
+  family
+  values map[string]*counterValue
+  sync.Mutex
+}
+
+// Set - Sets the gauge for the given label values.
+func (gauge *Gauge) Set(value float64, labelValues ...string) {
+  key := gauge.key(labelValues)
+
+  gauge.Lock()
+  defer gauge.Unlock()
+
+  gauge.values[key] = &counterValue{append([]string{}, labelValues...), value}
+}
+
+// Value - Current value for the given label values.
+func (gauge *Gauge) Value(labelValues ...string) float64 {
+  key := gauge.key(labelValues)
+
+  gauge.Lock()
+  defer gauge.Unlock()
+
+  if value, ok := gauge.values[key]; ok {
+    return value.value
+  }
+
+  return 0
+}
+
+func (gauge *Gauge) write(buffer *bytes.Buffer) {
+  gauge.Lock()
+  defer gauge.Unlock()
+
+  gauge.header(buffer, "gauge")
+
+  for _, key := range sortedKeys(gauge.values) {
+    value := gauge.values[key]
+    fmt.Fprintf(buffer, "%s%s %s\n", gauge.name, gauge.labelPairs(value.labels), formatFloat(value.value))
+  }
+}
+
+type gaugeFunc struct {
+  family
+  value func() float64
+}
+
+func (gauge *gaugeFunc) write(buffer *bytes.Buffer) {
+  gauge.header(buffer, "gauge")
+  fmt.Fprintf(buffer, "%s %s\n", gauge.name, formatFloat(gauge.value()))
+}
+
+func sortedKeys(values interface{}) []string {
+  keys := []string{}
+
+  switch typed := values.(type) {
+  case map[string]*counterValue:
+    for key := range typed {
+      keys = append(keys, key)
+    }
+  case map[string]*histogramValue:
+    for key := range typed {
+      keys = append(keys, key)
+    }
+  }
+
+  sort.Strings(keys)
+  return keys
+}
+
+func formatFloat(value float64) string {
+  switch {
+  case math.IsInf(value, 1):
+    return "+Inf"
+  case math.IsInf(value, -1):
+    return "-Inf"
+  case math.IsNaN(value):
+    return "NaN"
+  }
+
+  return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+func escapeHelp(help string) string {
+  return strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(help)
+}
+
+func escapeLabel(value string) string {
+  return strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`).Replace(value)
+}
@@ -0,0 +1,97 @@
+package metrics
+
+import (
+  "bytes"
+  "net/http/httptest"
+  "testing"
+
+  "github.com/stretchr/testify/assert"
+)
+
+func TestCounter(t *testing.T) {
+  registry := NewRegistry()
+  counter := registry.NewCounter("kueski_calls_total", "Calls made.", "endpoint", "code")
+
+  counter.Inc("lead-data", "201")
+  counter.Add(2, "lead-data", "201")
+  counter.Add(-1, "lead-data", "201")
+  counter.Inc("authenticate", "401")
+
+  assert.Equal(t, 3.0, counter.Value("lead-data", "201"))
+  assert.Equal(t, 0.0, counter.Value("lead-data", "500"))
+  assert.Panics(t, func() { counter.Inc("only-one") })
+
+  var buffer bytes.Buffer
+  registry.WriteTo(&buffer)
+
+  assert.Equal(t, "# HELP kueski_calls_total Calls made.\n"+
+    "# TYPE kueski_calls_total counter\n"+
+    "kueski_calls_total{endpoint=\"authenticate\",code=\"401\"} 1\n"+
+    "kueski_calls_total{endpoint=\"lead-data\",code=\"201\"} 3\n", buffer.String())
+}
+
+func TestHistogram(t *testing.T) {
+  registry := NewRegistry()
+  histogram := registry.NewHistogram("kueski_latency_seconds", "Latency.", []float64{0.1, 1}, "phase")
+
+  histogram.Observe(0.05, "token")
+  histogram.Observe(0.5, "token")
+  histogram.Observe(3, "token")
+
+  assert.Equal(t, uint64(3), histogram.Count("token"))
+  assert.Equal(t, uint64(0), histogram.Count("other"))
+
+  var buffer bytes.Buffer
+  registry.WriteTo(&buffer)
+
+  assert.Equal(t, "# HELP kueski_latency_seconds Latency.\n"+
+    "# TYPE kueski_latency_seconds histogram\n"+
+    "kueski_latency_seconds_bucket{phase=\"token\",le=\"0.1\"} 1\n"+
+    "kueski_latency_seconds_bucket{phase=\"token\",le=\"1\"} 2\n"+
+    "kueski_latency_seconds_bucket{phase=\"token\",le=\"+Inf\"} 3\n"+
+    "kueski_latency_seconds_sum{phase=\"token\"} 3.55\n"+
+    "kueski_latency_seconds_count{phase=\"token\"} 3\n", buffer.String())
+}
+
+func TestGauge(t *testing.T) {
+  registry := NewRegistry()
+  gauge := registry.NewGauge("kueski_state", "State.", "host")
+
+  gauge.Set(2, "http://primary")
+  gauge.Set(1, "http://dr")
+  gauge.Set(0, "http://primary")
+
+  assert.Equal(t, 0.0, gauge.Value("http://primary"))
+  assert.Equal(t, 1.0, gauge.Value("http://dr"))
+  assert.Equal(t, 0.0, gauge.Value("http://other"))
+
+  var buffer bytes.Buffer
+  registry.WriteTo(&buffer)
+
+  assert.Equal(t, "# HELP kueski_state State.\n"+
+    "# TYPE kueski_state gauge\n"+
+    "kueski_state{host=\"http://dr\"} 1\n"+
+    "kueski_state{host=\"http://primary\"} 0\n", buffer.String())
+}
+
+func TestGaugeFuncAndHandler(t *testing.T) {
+  registry := NewRegistry()
+  registry.NewGaugeFunc("kueski_gauge", "A \"quoted\"\nhelp.", func() float64 { return 42.5 })
+
+  recorder := httptest.NewRecorder()
+  registry.ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+
+  assert.Equal(t, ContentType, recorder.Header().Get("Content-Type"))
+  assert.Equal(t, "# HELP kueski_gauge A \"quoted\"\\nhelp.\n# TYPE kueski_gauge gauge\nkueski_gauge 42.5\n", recorder.Body.String())
+}
+
+func TestLabelEscaping(t *testing.T) {
+  registry := NewRegistry()
+  counter := registry.NewCounter("kueski_total", "Total.", "name")
+  counter.Inc("a\"b\\c")
+
+  var buffer bytes.Buffer
+  registry.WriteTo(&buffer)
+
+  assert.Contains(t, buffer.String(), `kueski_total{name="a\"b\\c"} 1`)
+}
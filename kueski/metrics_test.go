@@ -0,0 +1,77 @@
+package kueski
+
+import (
+  "context"
+  "fmt"
+  "net/http"
+  "net/http/httptest"
+  "testing"
+  "time"
+
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski/errors"
+  "github.com/stretchr/testify/assert"
+)
+
+func TestMetricsFromEvaluate(t *testing.T) {
+  expiration := time.Now().Add(time.Hour)
+  responses := map[string]*http.Response{}
+  client := NewClient("http://kueski.com", "Key", "Secret")
+  client.validator = func(curp, email string, fullData interface{}) error { return nil }
+  client.requester = func(url string, headers map[string]string, body []byte) (*http.Response, error) {
+    if response, ok := responses[url]; ok {
+      return response, nil
+    }
+
+    return nil, errors.UnableToMakeConnection
+  }
+
+  collector := client.EnableMetrics()
+
+  responses["http://kueski.com/affiliates/authenticate"] = buildHTTPResponse(201, fmt.Sprintf(`{"token": "Token", "expiration": %d}`, expiration.Unix()))
+  responses["http://kueski.com/affiliates/lead-evaluation"] = buildHTTPResponse(201, fmt.Sprintf(`{ "curp": "%s", "email": "%s", "request_id": "%s", "status": "duplicated" }`, curp, email, requestID))
+  client.Evaluate(curp, email, "data")
+
+  assert.Equal(t, 1.0, collector.requests.Value("authenticate", "201"))
+  assert.Equal(t, 1.0, collector.requests.Value("lead-evaluation", "201"))
+  assert.Equal(t, 1.0, collector.leads.Value("duplicated"))
+  assert.Equal(t, 1.0, collector.failures.Value(StageLeadEvaluation, "DuplicatedLead"))
+  assert.Equal(t, 1.0, collector.tokenRefreshes.Value())
+  assert.Equal(t, uint64(1), collector.phaseLatency.Count(StageEvaluate))
+  assert.Equal(t, uint64(1), collector.phaseLatency.Count(StageValidation))
+  assert.Equal(t, uint64(0), collector.phaseLatency.Count(StageLeadData))
+  assert.InDelta(t, 3600, collector.tokenTimeToExpiry(), 5)
+
+  responses["http://kueski.com/affiliates/lead-evaluation"] = buildHTTPResponse(201, fmt.Sprintf(`{ "curp": "%s", "email": "%s", "request_id": "%s", "status": "approved" }`, curp, email, requestID))
+  client.Evaluate(curp, email, "data")
+
+  assert.Equal(t, 1.0, collector.leads.Value("approved"))
+  assert.Equal(t, 1.0, collector.requests.Value("lead-data", "error"))
+  assert.Equal(t, 1.0, collector.failures.Value(StageLeadData, "UnableToMakeConnection"))
+
+  recorder := httptest.NewRecorder()
+  collector.ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+
+  assert.Contains(t, recorder.Body.String(), `kueski_leads_total{status="approved"} 1`)
+  assert.Contains(t, recorder.Body.String(), `kueski_requests_total{endpoint="lead-evaluation",code="201"} 2`)
+  assert.Contains(t, recorder.Body.String(), "# TYPE kueski_token_expiry_seconds gauge")
+}
+
+func TestTokenFailureMetrics(t *testing.T) {
+  client := Client{}
+  client.jwtProvider = &fakeTokenProvider{false}
+  collector := client.EnableMetrics()
+
+  client.makeRequest(context.Background(), "path", []byte(""))
+
+  assert.Equal(t, 1.0, collector.tokenFailures.Value())
+  assert.Equal(t, 1.0, collector.failures.Value(StageAuthentication, "GeneralError"))
+  assert.Equal(t, 0.0, collector.tokenTimeToExpiry())
+}
+
+func TestEndpointAndErrorNames(t *testing.T) {
+  assert.Equal(t, "lead-data", endpointName("https://kueski.com/v1/affiliates/lead-data"))
+  assert.Equal(t, "unknown", endpointName("https://kueski.com"))
+  assert.Equal(t, "unknown", endpointName("%zz"))
+  assert.Equal(t, "AccessDenied", errorName(errors.AccessDenied))
+  assert.Equal(t, "Unknown", errorName(fmt.Errorf("other")))
+}
@@ -0,0 +1,108 @@
+// Package reconcile matches the Kueski settlement reports against the leads submitted by the affiliate.
+package reconcile
+
+import (
+  "sort"
+
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski"
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski/commission"
+)
+
+// Submission - A lead we submitted: the request ID returned by Evaluate and the last status we know of.
+type Submission struct {
+  RequestID string        `json:"request_id"`
+  Campaign  string        `json:"campaign,omitempty"`
+  Status    kueski.Status `json:"status"`
+}
+
+// Match - A submission and the credit of the same request ID.
+type Match struct {
+  Submission Submission `json:"submission"`
+  Credit     Credit     `json:"credit"`
+}
+
+// Result - Outcome of a reconciliation, each set sorted by request ID.
+// Matched        - Credited with the status we know of.
+// StatusMismatch - Credited with another status.
+// MissingTheirs  - Submitted but not credited.
+// MissingOurs    - Credited but not submitted by us.
+// Duplicates     - Credits sharing their request ID with another credit of the report, in report order
+//                  within a request ID. Only the first one of each request ID is matched.
+type Result struct {
+  Matched        []Match      `json:"matched"`
+  StatusMismatch []Match      `json:"status_mismatch"`
+  MissingTheirs  []Submission `json:"missing_theirs"`
+  MissingOurs    []Credit     `json:"missing_ours"`
+  Duplicates     []Credit     `json:"duplicates"`
+}
+
+// FromConversions - Submissions of the conversions recorded by a commission.Tracker.
+func FromConversions(conversions []commission.Conversion) []Submission {
+  submissions := []Submission{}
+
+  for _, conversion := range conversions {
+    submissions = append(submissions, Submission{conversion.RequestID, conversion.Campaign, conversion.Status})
+  }
+
+  return submissions
+}
+
+// creditable - Statuses a submission is expected to be credited for.
+var creditable = map[kueski.Status]bool{kueski.StatusApproved: true, kueski.StatusFunded: true}
+
+// Reconcile - Joins the submissions with the credits of a report by request ID.
+// Only approved or funded submissions are reported missing on their side, the others
+// just match credits. A credit without status matches any status.
+func Reconcile(submissions []Submission, credits []Credit) Result {
+  result := Result{[]Match{}, []Match{}, []Submission{}, []Credit{}, []Credit{}}
+  credited := map[string]Credit{}
+  counts := map[string]int{}
+
+  for _, credit := range credits {
+    if counts[credit.RequestID] == 0 {
+      credited[credit.RequestID] = credit
+    }
+
+    counts[credit.RequestID]++
+  }
+
+  submitted := map[string]bool{}
+
+  for _, submission := range submissions {
+    submitted[submission.RequestID] = true
+    credit, ok := credited[submission.RequestID]
+
+    switch {
+    case !ok && creditable[submission.Status]:
+      result.MissingTheirs = append(result.MissingTheirs, submission)
+    case !ok:
+      // Not credited, and not expected to be.
+    case credit.Status == "" || credit.Status == submission.Status:
+      result.Matched = append(result.Matched, Match{submission, credit})
+    default:
+      result.StatusMismatch = append(result.StatusMismatch, Match{submission, credit})
+    }
+  }
+
+  for _, credit := range credits {
+    if !submitted[credit.RequestID] {
+      result.MissingOurs = append(result.MissingOurs, credit)
+    }
+
+    if counts[credit.RequestID] > 1 {
+      result.Duplicates = append(result.Duplicates, credit)
+    }
+  }
+
+  sortMatches(result.Matched)
+  sortMatches(result.StatusMismatch)
+  sort.Slice(result.MissingTheirs, func(i, j int) bool { return result.MissingTheirs[i].RequestID < result.MissingTheirs[j].RequestID })
+  sort.Slice(result.MissingOurs, func(i, j int) bool { return result.MissingOurs[i].RequestID < result.MissingOurs[j].RequestID })
+  sort.SliceStable(result.Duplicates, func(i, j int) bool { return result.Duplicates[i].RequestID < result.Duplicates[j].RequestID })
+
+  return result
+}
+
+func sortMatches(matches []Match) {
+  sort.Slice(matches, func(i, j int) bool { return matches[i].Submission.RequestID < matches[j].Submission.RequestID })
+}
@@ -0,0 +1,88 @@
+package reconcile
+
+import (
+  "strings"
+  "testing"
+  "time"
+
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski"
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski/commission"
+  "github.com/stretchr/testify/assert"
+)
+
+func TestParseCSV(t *testing.T) {
+  report := "Status,Request_ID,Amount,Credited_At\nfunded,1,250.50,2026-03-02\napproved,2,,2026-03-03T10:00:00Z\n"
+  credits, err := ParseCSV(strings.NewReader(report))
+
+  assert.Nil(t, err)
+  assert.Equal(t, []Credit{
+    {"1", kueski.StatusFunded, 25050, time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)},
+    {"2", kueski.StatusApproved, 0, time.Date(2026, 3, 3, 10, 0, 0, 0, time.UTC)},
+  }, credits)
+
+  _, err = ParseCSV(strings.NewReader("status\nfunded\n"))
+  assert.NotNil(t, err)
+
+  _, err = ParseCSV(strings.NewReader("request_id,amount\n1,lots\n"))
+  assert.Equal(t, `report line 2: invalid amount "lots"`, err.Error())
+}
+
+func TestParseJSON(t *testing.T) {
+  credits, err := ParseJSON(strings.NewReader(`[{ "request_id": "1", "status": "FUNDED", "amount": 100 }]`))
+  assert.Nil(t, err)
+  assert.Equal(t, []Credit{{RequestID: "1", Status: kueski.StatusFunded, Amount: 10000}}, credits)
+
+  credits, err = ParseJSON(strings.NewReader(`{ "leads": [{ "request_id": "2", "amount": "1.5" }] }`))
+  assert.Nil(t, err)
+  assert.Equal(t, []Credit{{RequestID: "2", Amount: 150}}, credits)
+
+  _, err = ParseJSON(strings.NewReader(`[{ "status": "funded" }]`))
+  assert.NotNil(t, err)
+
+  _, err = ParseJSON(strings.NewReader(`not json`))
+  assert.NotNil(t, err)
+}
+
+func TestReconcile(t *testing.T) {
+  submissions := FromConversions([]commission.Conversion{
+    {RequestID: "1", Status: kueski.StatusFunded},
+    {RequestID: "2", Status: kueski.StatusApproved},
+    {RequestID: "3", Status: kueski.StatusFunded},
+    {RequestID: "4", Status: kueski.StatusInProgress},
+    {RequestID: "5", Status: kueski.StatusRejected},
+    {RequestID: "6", Status: kueski.StatusApproved, Campaign: "summer"},
+  })
+  credits := []Credit{
+    {RequestID: "1", Status: kueski.StatusFunded},
+    {RequestID: "2", Status: kueski.StatusFunded},
+    {RequestID: "4", Status: kueski.StatusApproved},
+    {RequestID: "6"},
+    {RequestID: "9", Status: kueski.StatusFunded},
+  }
+
+  result := Reconcile(submissions, credits)
+
+  assert.Equal(t, []Match{{submissions[0], credits[0]}, {submissions[5], credits[3]}}, result.Matched)
+  assert.Equal(t, []Match{{submissions[1], credits[1]}, {submissions[3], credits[2]}}, result.StatusMismatch)
+  assert.Equal(t, []Submission{submissions[2]}, result.MissingTheirs)
+  assert.Equal(t, []Credit{credits[4]}, result.MissingOurs)
+  assert.Equal(t, []Credit{}, result.Duplicates)
+}
+
+func TestReconcileDuplicates(t *testing.T) {
+  submissions := []Submission{{RequestID: "1", Status: kueski.StatusFunded}, {RequestID: "2", Status: kueski.StatusFunded}}
+  credits := []Credit{
+    {RequestID: "2", Status: kueski.StatusFunded, Amount: 100},
+    {RequestID: "1", Status: kueski.StatusFunded, Amount: 250},
+    {RequestID: "9", Amount: 80},
+    {RequestID: "1", Status: kueski.StatusApproved, Amount: 250},
+    {RequestID: "9", Amount: 80},
+  }
+
+  result := Reconcile(submissions, credits)
+
+  assert.Equal(t, []Match{{submissions[0], credits[1]}, {submissions[1], credits[0]}}, result.Matched)
+  assert.Equal(t, []Match{}, result.StatusMismatch)
+  assert.Equal(t, []Credit{credits[2], credits[4]}, result.MissingOurs)
+  assert.Equal(t, []Credit{credits[1], credits[3], credits[2], credits[4]}, result.Duplicates)
+}
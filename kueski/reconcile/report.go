@@ -0,0 +1,154 @@
+package reconcile
+
+import (
+  "encoding/csv"
+  "encoding/json"
+  "fmt"
+  "io"
+  "math"
+  "strconv"
+  "strings"
+  "time"
+
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski"
+)
+
+// Credit - A lead credited in a Kueski settlement report. Amount is in cents.
+type Credit struct {
+  RequestID  string        `json:"request_id"`
+  Status     kueski.Status `json:"status"`
+  Amount     int64         `json:"amount"`
+  CreditedAt time.Time     `json:"credited_at"`
+}
+
+// reportCredit - Credit as written in the reports: amounts in pesos, dates as RFC 3339 or YYYY-MM-DD.
+type reportCredit struct {
+  RequestID  string      `json:"request_id"`
+  Status     string      `json:"status"`
+  Amount     json.Number `json:"amount"`
+  CreditedAt string      `json:"credited_at"`
+}
+
+// ParseCSV - Reads a CSV settlement report. The header row names the columns:
+// request_id (required), status, amount (pesos, e.g. 250.00) and credited_at, in any order.
+func ParseCSV(reader io.Reader) ([]Credit, error) {
+  rows, err := csv.NewReader(reader).ReadAll()
+
+  if err != nil {
+    return nil, err
+  }
+
+  if len(rows) == 0 {
+    return []Credit{}, nil
+  }
+
+  columns := map[string]int{}
+
+  for i, name := range rows[0] {
+    columns[strings.ToLower(strings.TrimSpace(name))] = i
+  }
+
+  if _, ok := columns["request_id"]; !ok {
+    return nil, fmt.Errorf("report has no request_id column")
+  }
+
+  field := func(row []string, name string) string {
+    if i, ok := columns[name]; ok && i < len(row) {
+      return strings.TrimSpace(row[i])
+    }
+
+    return ""
+  }
+
+  credits := []Credit{}
+
+  for line, row := range rows[1:] {
+    credit, err := parseCredit(reportCredit{
+      field(row, "request_id"), field(row, "status"), json.Number(field(row, "amount")), field(row, "credited_at"),
+    })
+
+    if err != nil {
+      return nil, fmt.Errorf("report line %d: %v", line+2, err)
+    }
+
+    credits = append(credits, credit)
+  }
+
+  return credits, nil
+}
+
+// ParseJSON - Reads a JSON settlement report: an array of credits, or an object with them under "leads".
+// Credits have the fields of the CSV columns.
+func ParseJSON(reader io.Reader) ([]Credit, error) {
+  var raw json.RawMessage
+
+  if err := json.NewDecoder(reader).Decode(&raw); err != nil {
+    return nil, err
+  }
+
+  var entries []reportCredit
+
+  if err := json.Unmarshal(raw, &entries); err != nil {
+    var wrapped struct {
+      Leads []reportCredit `json:"leads"`
+    }
+
+    if wrappedErr := json.Unmarshal(raw, &wrapped); wrappedErr != nil {
+      return nil, err
+    }
+
+    entries = wrapped.Leads
+  }
+
+  credits := []Credit{}
+
+  for i, entry := range entries {
+    credit, err := parseCredit(entry)
+
+    if err != nil {
+      return nil, fmt.Errorf("report entry %d: %v", i+1, err)
+    }
+
+    credits = append(credits, credit)
+  }
+
+  return credits, nil
+}
+
+func parseCredit(entry reportCredit) (Credit, error) {
+  credit := Credit{RequestID: entry.RequestID, Status: kueski.Status(strings.ToLower(entry.Status))}
+
+  if credit.RequestID == "" {
+    return credit, fmt.Errorf("missing request_id")
+  }
+
+  if entry.Amount != "" {
+    pesos, err := strconv.ParseFloat(string(entry.Amount), 64)
+
+    if err != nil {
+      return credit, fmt.Errorf("invalid amount %q", entry.Amount)
+    }
+
+    credit.Amount = int64(math.Round(pesos * 100))
+  }
+
+  if entry.CreditedAt != "" {
+    creditedAt, err := parseDate(entry.CreditedAt)
+
+    if err != nil {
+      return credit, fmt.Errorf("invalid credited_at %q", entry.CreditedAt)
+    }
+
+    credit.CreditedAt = creditedAt
+  }
+
+  return credit, nil
+}
+
+func parseDate(value string) (time.Time, error) {
+  if date, err := time.Parse(time.RFC3339, value); err == nil {
+    return date, nil
+  }
+
+  return time.Parse("2006-01-02", value)
+}
@@ -0,0 +1,11 @@
+// Code generated by go run ./gen from testdata/CPdescarga.txt; DO NOT EDIT.
+
+package sepomex
+
+// embedded - Postal codes in the format of Dataset.Encode.
+const embedded = `06600|DF|Cuauhtémoc|Ciudad de México|Colonia:Juárez
+06700|DF|Cuauhtémoc|Ciudad de México|Colonia:Roma Norte
+44100|JC|Guadalajara|Guadalajara|Colonia:Guadalajara Centro
+64000|NL|Monterrey|Monterrey|Colonia:Monterrey Centro
+97000|YN|Mérida|Mérida|Colonia:Mérida Centro
+`
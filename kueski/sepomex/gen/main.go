@@ -0,0 +1,50 @@
+// Command gen converts the SEPOMEX postal code catalog (CPdescarga.txt) into the dataset embedded by
+// the sepomex package.
+package main
+
+import (
+  "bytes"
+  "flag"
+  "fmt"
+  "io/ioutil"
+  "os"
+
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski/sepomex"
+)
+
+func main() {
+  in := flag.String("in", "CPdescarga.txt", "SEPOMEX catalog, as downloaded")
+  out := flag.String("out", "data.go", "Go file to write")
+  flag.Parse()
+
+  if err := generate(*in, *out); err != nil {
+    fmt.Fprintln(os.Stderr, err)
+    os.Exit(1)
+  }
+}
+
+func generate(in, out string) error {
+  file, err := os.Open(in)
+
+  if err != nil {
+    return err
+  }
+
+  defer file.Close()
+
+  dataset, err := sepomex.Load(file)
+
+  if err != nil {
+    return err
+  }
+
+  var compact bytes.Buffer
+
+  if err := dataset.Encode(&compact); err != nil {
+    return err
+  }
+
+  source := fmt.Sprintf("// Code generated by go run ./gen from %s; DO NOT EDIT.\n\npackage sepomex\n\n"+
+    "// embedded - Postal codes in the format of Dataset.Encode.\nconst embedded = `%s`\n", in, compact.String())
+  return ioutil.WriteFile(out, []byte(source), 0644)
+}
@@ -0,0 +1,54 @@
+package sepomex
+
+import (
+  "time"
+
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski"
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski/errors"
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski/util"
+)
+
+// Address - Postal code and declared residence state (RENAPO code or name) of the full data, empty when missing.
+type Address func(fullData interface{}) (postalCode, state string)
+
+// StateRule - Rule for kueski.Client.AddRules cross-checking the postal code of the full data with the
+// declared state or, when none is declared, the CURP birth state unless born abroad. Fails with
+// InvalidPostalCode when malformed, or missing from the dataset if one is given, and with PostalCodeStateMismatch.
+// Leads without postal code pass.
+func StateRule(dataset *Dataset, address Address) kueski.Rule {
+  return func(curp, email string, fullData interface{}) error {
+    postalCode, declared := address(fullData)
+
+    if postalCode == "" {
+      return nil
+    }
+
+    assigned, ok := State(postalCode)
+
+    if !ok {
+      return errors.InvalidPostalCode
+    }
+
+    if dataset != nil {
+      if _, ok := dataset.Lookup(postalCode); !ok {
+        return errors.InvalidPostalCode
+      }
+    }
+
+    expected := ""
+
+    if declared != "" {
+      if expected, ok = StateCode(declared); !ok {
+        return errors.PostalCodeStateMismatch
+      }
+    } else if info, err := util.ParseCurp(curp, time.Now()); err == nil && !info.BornAbroad {
+      expected = info.StateCode
+    }
+
+    if expected != "" && expected != assigned {
+      return errors.PostalCodeStateMismatch
+    }
+
+    return nil
+  }
+}
@@ -0,0 +1,40 @@
+package sepomex
+
+import (
+  "testing"
+
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski/errors"
+  "github.com/stretchr/testify/assert"
+)
+
+type lead struct {
+  PostalCode string
+  State      string
+}
+
+func leadAddress(fullData interface{}) (string, string) {
+  return fullData.(lead).PostalCode, fullData.(lead).State
+}
+
+func TestStateRule(t *testing.T) {
+  rule := StateRule(nil, leadAddress)
+  jalisco := "GAMA850704MJCRXN04"
+  abroad := "BADD110313HNELNS07"
+
+  assert.Nil(t, rule(jalisco, "e@mail.com", lead{"", ""}))
+  assert.Nil(t, rule(jalisco, "e@mail.com", lead{"44100", ""}))
+  assert.Nil(t, rule(jalisco, "e@mail.com", lead{"64000", "Nuevo León"}))
+  assert.Nil(t, rule(abroad, "e@mail.com", lead{"64000", ""}))
+  assert.Nil(t, rule(jalisco, "e@mail.com", lead{"45999", ""}))
+  assert.Equal(t, errors.PostalCodeStateMismatch, rule(jalisco, "e@mail.com", lead{"64000", ""}))
+  assert.Equal(t, errors.PostalCodeStateMismatch, rule(jalisco, "e@mail.com", lead{"44100", "NL"}))
+  assert.Equal(t, errors.PostalCodeStateMismatch, rule(jalisco, "e@mail.com", lead{"44100", "Atlantis"}))
+  assert.Equal(t, errors.InvalidPostalCode, rule(jalisco, "e@mail.com", lead{"4410", ""}))
+}
+
+func TestStateRuleWithDataset(t *testing.T) {
+  rule := StateRule(Embedded(), leadAddress)
+
+  assert.Nil(t, rule("GAMA850704MJCRXN04", "e@mail.com", lead{"44100", ""}))
+  assert.Equal(t, errors.InvalidPostalCode, rule("GAMA850704MJCRXN04", "e@mail.com", lead{"45999", ""}))
+}
@@ -0,0 +1,252 @@
+// Package sepomex looks up Mexican postal codes offline: state, municipality and settlements (colonias),
+// from a compact dataset derived from the SEPOMEX catalog, and cross-checks them with the lead state.
+package sepomex
+
+//go:generate go run ./gen -in testdata/CPdescarga.txt -out data.go
+
+import (
+  "bufio"
+  "fmt"
+  "io"
+  "io/ioutil"
+  "regexp"
+  "sort"
+  "strconv"
+  "strings"
+  "sync"
+  "unicode/utf8"
+)
+
+// Settlement - A colonia, fraccionamiento, pueblo, etc. of a postal code.
+type Settlement struct {
+  Name string
+  Type string
+}
+
+// PostalCode - A postal code and where it is.
+// StateCode - RENAPO code of the state, as in the CURP.
+type PostalCode struct {
+  Code         string
+  StateCode    string
+  State        string
+  Municipality string
+  City         string
+  Settlements  []Settlement
+}
+
+// Dataset - Postal codes by code.
+type Dataset struct {
+  codes map[string]*PostalCode
+}
+
+var codeRegex = regexp.MustCompile(`^\d{5}$`)
+
+// compactEscapes - Separators of the compact format removed from names.
+var compactEscapes = strings.NewReplacer("|", " ", ";", " ", ":", " ", "\n", " ", "`", "'")
+
+// Load - Reads the postal code catalog as downloaded from SEPOMEX (CPdescarga.txt): pipe separated,
+// Latin-1 or UTF-8, with a notice line before the header.
+func Load(reader io.Reader) (*Dataset, error) {
+  blob, err := ioutil.ReadAll(reader)
+
+  if err != nil {
+    return nil, err
+  }
+
+  if !utf8.Valid(blob) {
+    blob = latin1ToUTF8(blob)
+  }
+
+  dataset := &Dataset{codes: map[string]*PostalCode{}}
+  var columns map[string]int
+
+  scanner := bufio.NewScanner(strings.NewReader(string(blob)))
+  scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+  for scanner.Scan() {
+    fields := strings.Split(strings.TrimRight(scanner.Text(), "\r"), "|")
+
+    if columns == nil {
+      if fields[0] == "d_codigo" {
+        columns = map[string]int{}
+
+        for i, name := range fields {
+          columns[strings.ToLower(name)] = i
+        }
+      }
+
+      continue
+    }
+
+    field := func(name string) string {
+      if i, ok := columns[name]; ok && i < len(fields) {
+        return strings.TrimSpace(fields[i])
+      }
+
+      return ""
+    }
+
+    state, ok := stateOfInegi(field("c_estado"))
+
+    if !codeRegex.MatchString(field("d_codigo")) || !ok {
+      return nil, fmt.Errorf("invalid catalog line %q", scanner.Text())
+    }
+
+    postalCode := dataset.codes[field("d_codigo")]
+
+    if postalCode == nil {
+      postalCode = &PostalCode{
+        Code:         field("d_codigo"),
+        StateCode:    state.renapo,
+        State:        state.name,
+        Municipality: field("d_mnpio"),
+        City:         field("d_ciudad"),
+      }
+      dataset.codes[postalCode.Code] = postalCode
+    }
+
+    postalCode.Settlements = append(postalCode.Settlements, Settlement{field("d_asenta"), field("d_tipo_asenta")})
+  }
+
+  if err := scanner.Err(); err != nil {
+    return nil, err
+  }
+
+  if columns == nil {
+    return nil, fmt.Errorf("catalog has no d_codigo header")
+  }
+
+  return dataset, nil
+}
+
+// Decode - Reads a dataset in the compact format written by Encode.
+func Decode(reader io.Reader) (*Dataset, error) {
+  dataset := &Dataset{codes: map[string]*PostalCode{}}
+  scanner := bufio.NewScanner(reader)
+  scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+  for line := 1; scanner.Scan(); line++ {
+    if scanner.Text() == "" {
+      continue
+    }
+
+    fields := strings.Split(scanner.Text(), "|")
+
+    if len(fields) != 5 || !codeRegex.MatchString(fields[0]) || StateName(fields[1]) == "" {
+      return nil, fmt.Errorf("invalid dataset line %d", line)
+    }
+
+    postalCode := &PostalCode{fields[0], fields[1], StateName(fields[1]), fields[2], fields[3], []Settlement{}}
+
+    for _, settlement := range strings.Split(fields[4], ";") {
+      parts := strings.SplitN(settlement, ":", 2)
+
+      if len(parts) != 2 {
+        return nil, fmt.Errorf("invalid dataset line %d", line)
+      }
+
+      postalCode.Settlements = append(postalCode.Settlements, Settlement{parts[1], parts[0]})
+    }
+
+    dataset.codes[postalCode.Code] = postalCode
+  }
+
+  return dataset, scanner.Err()
+}
+
+// Encode - Writes the dataset in a compact format, one postal code per line sorted by code:
+// code|RENAPO state code|municipality|city|type:settlement;type:settlement...
+func (dataset *Dataset) Encode(writer io.Writer) error {
+  codes := make([]string, 0, len(dataset.codes))
+
+  for code := range dataset.codes {
+    codes = append(codes, code)
+  }
+
+  sort.Strings(codes)
+
+  for _, code := range codes {
+    postalCode := dataset.codes[code]
+    settlements := []string{}
+
+    for _, settlement := range postalCode.Settlements {
+      settlements = append(settlements, compactEscapes.Replace(settlement.Type)+":"+compactEscapes.Replace(settlement.Name))
+    }
+
+    _, err := fmt.Fprintf(writer, "%s|%s|%s|%s|%s\n", code, postalCode.StateCode,
+      compactEscapes.Replace(postalCode.Municipality), compactEscapes.Replace(postalCode.City), strings.Join(settlements, ";"))
+
+    if err != nil {
+      return err
+    }
+  }
+
+  return nil
+}
+
+// Lookup - Data of the postal code, if in the dataset.
+func (dataset *Dataset) Lookup(code string) (PostalCode, bool) {
+  postalCode, ok := dataset.codes[code]
+
+  if !ok {
+    return PostalCode{}, false
+  }
+
+  found := *postalCode
+  found.Settlements = append([]Settlement{}, postalCode.Settlements...)
+  return found, true
+}
+
+// Len - Number of postal codes in the dataset.
+func (dataset *Dataset) Len() int {
+  return len(dataset.codes)
+}
+
+var (
+  embeddedOnce    sync.Once
+  embeddedDataset *Dataset
+)
+
+// Embedded - The dataset compiled into the package, see data.go. Regenerate it from the full
+// SEPOMEX download with go generate, or load the download at runtime with Load.
+func Embedded() *Dataset {
+  embeddedOnce.Do(func() {
+    dataset, err := Decode(strings.NewReader(embedded))
+
+    if err != nil {
+      panic("sepomex: invalid embedded dataset: " + err.Error())
+    }
+
+    embeddedDataset = dataset
+  })
+
+  return embeddedDataset
+}
+
+// State - RENAPO code of the state a postal code is assigned to, from its first two digits.
+// Needs no dataset, but does not tell whether the postal code exists.
+func State(code string) (string, bool) {
+  if !codeRegex.MatchString(code) {
+    return "", false
+  }
+
+  prefix, _ := strconv.Atoi(code[:2])
+
+  for _, assigned := range prefixes {
+    if prefix >= assigned.from && prefix <= assigned.to {
+      return assigned.renapo, true
+    }
+  }
+
+  return "", false
+}
+
+func latin1ToUTF8(blob []byte) []byte {
+  runes := make([]rune, len(blob))
+
+  for i, char := range blob {
+    runes[i] = rune(char)
+  }
+
+  return []byte(string(runes))
+}
@@ -0,0 +1,110 @@
+package sepomex
+
+import (
+  "bytes"
+  "os"
+  "strings"
+  "testing"
+
+  "github.com/stretchr/testify/assert"
+)
+
+func TestLoad(t *testing.T) {
+  file, err := os.Open("testdata/CPdescarga.txt")
+  assert.Nil(t, err)
+  defer file.Close()
+
+  dataset, err := Load(file)
+
+  assert.Nil(t, err)
+  assert.Equal(t, 5, dataset.Len())
+
+  postalCode, ok := dataset.Lookup("06700")
+
+  assert.True(t, ok)
+  assert.Equal(t, PostalCode{"06700", "DF", "Ciudad de México", "Cuauhtémoc", "Ciudad de México",
+    []Settlement{{"Roma Norte", "Colonia"}}}, postalCode)
+
+  _, ok = dataset.Lookup("06701")
+  assert.False(t, ok)
+
+  _, err = Load(strings.NewReader("d_codigo|d_asenta|c_estado\n0670|Roma Norte|09\n"))
+  assert.NotNil(t, err)
+
+  _, err = Load(strings.NewReader("06700|Roma Norte|09\n"))
+  assert.NotNil(t, err)
+}
+
+func TestEncodeDecode(t *testing.T) {
+  dataset, err := Load(strings.NewReader("d_codigo|d_asenta|d_tipo_asenta|D_mnpio|d_ciudad|c_estado\n" +
+    "99999|Uno|Colonia|Municipio|Ciudad|32\n99999|Dos; Tres|Barrio|Municipio|Ciudad|32\n"))
+  assert.Nil(t, err)
+
+  var compact bytes.Buffer
+  assert.Nil(t, dataset.Encode(&compact))
+  assert.Equal(t, "99999|ZS|Municipio|Ciudad|Colonia:Uno;Barrio:Dos  Tres\n", compact.String())
+
+  decoded, err := Decode(&compact)
+  assert.Nil(t, err)
+
+  postalCode, ok := decoded.Lookup("99999")
+
+  assert.True(t, ok)
+  assert.Equal(t, "Zacatecas", postalCode.State)
+  assert.Equal(t, []Settlement{{"Uno", "Colonia"}, {"Dos  Tres", "Barrio"}}, postalCode.Settlements)
+
+  _, err = Decode(strings.NewReader("99999|XX|Municipio|Ciudad|Colonia:Uno\n"))
+  assert.NotNil(t, err)
+}
+
+func TestEmbedded(t *testing.T) {
+  postalCode, ok := Embedded().Lookup("44100")
+
+  assert.True(t, ok)
+  assert.Equal(t, "JC", postalCode.StateCode)
+  assert.Equal(t, "Guadalajara", postalCode.Municipality)
+}
+
+func TestLookupOutsideFixture(t *testing.T) {
+  dataset, err := Load(strings.NewReader("El Catálogo Nacional de Códigos Postales...\n" +
+    "d_codigo|d_asenta|d_tipo_asenta|D_mnpio|d_estado|d_ciudad|c_estado\n" +
+    "45010|Jardines Universidad|Colonia|Zapopan|Jalisco|Zapopan|14\n" +
+    "83000|Hermosillo Centro|Colonia|Hermosillo|Sonora|Hermosillo|26\n"))
+  assert.Nil(t, err)
+
+  postalCode, ok := dataset.Lookup("45010")
+
+  assert.True(t, ok)
+  assert.Equal(t, "JC", postalCode.StateCode)
+  assert.Equal(t, "Zapopan", postalCode.Municipality)
+
+  postalCode, ok = dataset.Lookup("83000")
+
+  assert.True(t, ok)
+  assert.Equal(t, "Sonora", postalCode.State)
+}
+
+func TestState(t *testing.T) {
+  for code, state := range map[string]string{"01000": "DF", "16999": "DF", "44100": "JC", "50000": "MC", "97000": "YN", "99999": "ZS"} {
+    assigned, ok := State(code)
+
+    assert.True(t, ok, code)
+    assert.Equal(t, state, assigned, code)
+  }
+
+  for _, code := range []string{"00100", "17000", "19000", "4410", "4410A"} {
+    _, ok := State(code)
+    assert.False(t, ok, code)
+  }
+}
+
+func TestStateCode(t *testing.T) {
+  for name, code := range map[string]string{"jc": "JC", "Nuevo León": "NL", "NUEVO LEON": "NL", "CDMX": "DF", "Edo. Mex": "", "Edomex": "MC"} {
+    found, ok := StateCode(name)
+
+    assert.Equal(t, code != "", ok, name)
+    assert.Equal(t, code, found, name)
+  }
+
+  assert.Equal(t, "Yucatán", StateName("YN"))
+}
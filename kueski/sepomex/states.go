@@ -0,0 +1,104 @@
+package sepomex
+
+import (
+  "strings"
+)
+
+// state - A state, by the INEGI code SEPOMEX uses (c_estado), with its RENAPO code as in the CURP.
+type state struct {
+  inegi   string
+  renapo  string
+  name    string
+  aliases []string
+}
+
+// states - The 32 states in INEGI order. Aliases are other names SEPOMEX and people use.
+var states = []state{
+  {"01", "AS", "Aguascalientes", nil},
+  {"02", "BC", "Baja California", nil},
+  {"03", "BS", "Baja California Sur", nil},
+  {"04", "CC", "Campeche", nil},
+  {"05", "CL", "Coahuila", []string{"Coahuila de Zaragoza"}},
+  {"06", "CM", "Colima", nil},
+  {"07", "CS", "Chiapas", nil},
+  {"08", "CH", "Chihuahua", nil},
+  {"09", "DF", "Ciudad de México", []string{"CDMX", "Distrito Federal"}},
+  {"10", "DG", "Durango", nil},
+  {"11", "GT", "Guanajuato", nil},
+  {"12", "GR", "Guerrero", nil},
+  {"13", "HG", "Hidalgo", nil},
+  {"14", "JC", "Jalisco", nil},
+  {"15", "MC", "Estado de México", []string{"México", "Edomex"}},
+  {"16", "MN", "Michoacán", []string{"Michoacán de Ocampo"}},
+  {"17", "MS", "Morelos", nil},
+  {"18", "NT", "Nayarit", nil},
+  {"19", "NL", "Nuevo León", nil},
+  {"20", "OC", "Oaxaca", nil},
+  {"21", "PL", "Puebla", nil},
+  {"22", "QT", "Querétaro", []string{"Querétaro de Arteaga"}},
+  {"23", "QR", "Quintana Roo", nil},
+  {"24", "SP", "San Luis Potosí", nil},
+  {"25", "SL", "Sinaloa", nil},
+  {"26", "SR", "Sonora", nil},
+  {"27", "TC", "Tabasco", nil},
+  {"28", "TS", "Tamaulipas", nil},
+  {"29", "TL", "Tlaxcala", nil},
+  {"30", "VZ", "Veracruz", []string{"Veracruz de Ignacio de la Llave"}},
+  {"31", "YN", "Yucatán", nil},
+  {"32", "ZS", "Zacatecas", nil},
+}
+
+// prefixes - RENAPO code of the state each range of the first two postal code digits is assigned to.
+var prefixes = []struct {
+  from, to int
+  renapo   string
+}{
+  {1, 16, "DF"}, {20, 20, "AS"}, {21, 22, "BC"}, {23, 23, "BS"}, {24, 24, "CC"}, {25, 27, "CL"}, {28, 28, "CM"},
+  {29, 30, "CS"}, {31, 33, "CH"}, {34, 35, "DG"}, {36, 38, "GT"}, {39, 41, "GR"}, {42, 43, "HG"}, {44, 49, "JC"},
+  {50, 57, "MC"}, {58, 61, "MN"}, {62, 62, "MS"}, {63, 63, "NT"}, {64, 67, "NL"}, {68, 71, "OC"}, {72, 75, "PL"},
+  {76, 76, "QT"}, {77, 77, "QR"}, {78, 79, "SP"}, {80, 82, "SL"}, {83, 85, "SR"}, {86, 86, "TC"}, {87, 89, "TS"},
+  {90, 90, "TL"}, {91, 96, "VZ"}, {97, 97, "YN"}, {98, 99, "ZS"},
+}
+
+// stateNames - Accents stripped when comparing state names.
+var stateNames = strings.NewReplacer("Á", "A", "É", "E", "Í", "I", "Ó", "O", "Ú", "U", ".", "")
+
+// StateCode - RENAPO code of a state given by code, name or alias, in any case and with or without accents.
+func StateCode(nameOrCode string) (string, bool) {
+  wanted := stateNames.Replace(strings.ToUpper(strings.TrimSpace(nameOrCode)))
+
+  for _, state := range states {
+    if wanted == state.renapo || wanted == stateNames.Replace(strings.ToUpper(state.name)) {
+      return state.renapo, true
+    }
+
+    for _, alias := range state.aliases {
+      if wanted == stateNames.Replace(strings.ToUpper(alias)) {
+        return state.renapo, true
+      }
+    }
+  }
+
+  return "", false
+}
+
+// StateName - Name of the state of a RENAPO code.
+func StateName(renapo string) string {
+  for _, state := range states {
+    if state.renapo == renapo {
+      return state.name
+    }
+  }
+
+  return ""
+}
+
+func stateOfInegi(inegi string) (state, bool) {
+  for _, state := range states {
+    if state.inegi == inegi {
+      return state, true
+    }
+  }
+
+  return state{}, false
+}
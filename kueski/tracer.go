@@ -0,0 +1,104 @@
+package kueski
+
+import (
+  "context"
+  "reflect"
+  "time"
+
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski/errors"
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski/tracing"
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski/util"
+)
+
+// Span attribute keys set by the Client.
+const (
+  methodAttribute     string = "http.method"
+  urlAttribute        string = "http.url"
+  statusCodeAttribute string = "http.status_code"
+  endpointAttribute   string = "kueski.endpoint"
+  stageAttribute      string = "kueski.stage"
+  errorAttribute      string = "kueski.error_code"
+  requestIDAttribute  string = "kueski.request_id"
+)
+
+// SetTracer - Sets the tracer used to open spans for every Evaluate stage and API call.
+// Spans are children of the span carried by the context given to EvaluateContext.
+func (client *Client) SetTracer(tracer tracing.Tracer) {
+  client.tracer = tracer
+}
+
+func (client *Client) startSpan(ctx context.Context, name string) (context.Context, tracing.Span) {
+  tracer := client.tracer
+
+  if tracer == nil {
+    tracer = tracing.NoopTracer{}
+  }
+
+  return tracer.Start(ctx, name)
+}
+
+// stageFailure - Last failure reported to Failed by the stages nested in a stage.
+type stageFailure struct {
+  err error
+}
+
+type stageFailureKey struct{}
+
+// startStage - Opens the span of an Evaluate stage. The returned function ends it,
+// reporting the outcome to the span and to the PhaseCompleted and Failed hooks.
+// Failures of the whole evaluation are not reported to Failed, the failing stage already was.
+// Neither are failures a nested stage, e.g. authentication, already reported.
+func (client *Client) startStage(ctx context.Context, stage string) (context.Context, func(err error)) {
+  start := time.Now()
+  parent, _ := ctx.Value(stageFailureKey{}).(*stageFailure)
+  nested := &stageFailure{}
+  ctx = context.WithValue(ctx, stageFailureKey{}, nested)
+  ctx, span := client.startSpan(ctx, stage)
+  span.SetAttribute(stageAttribute, stage)
+
+  return ctx, func(err error) {
+    client.phaseCompleted(stage, start, err)
+
+    if err != nil && stage != StageEvaluate && !sameError(err, nested.err) {
+      client.failed(stage, err)
+    }
+
+    if err != nil && parent != nil {
+      parent.err = err
+    }
+
+    recordSpanError(span, err)
+    span.End()
+  }
+}
+
+// sameError - Both errors are the same value. Errors of non comparable types never are.
+func sameError(a, b error) bool {
+  if a == nil || b == nil || reflect.TypeOf(a) != reflect.TypeOf(b) || !reflect.TypeOf(a).Comparable() {
+    return false
+  }
+
+  return a == b
+}
+
+func recordSpanError(span tracing.Span, err error) {
+  if err == nil {
+    return
+  }
+
+  span.RecordError(err)
+
+  if responseError, ok := responseErrorOf(err); ok {
+    span.SetAttribute(errorAttribute, int(responseError))
+  }
+}
+
+// responseErrorOf - The ResponseError of err, including the InvalidFullDataFields of a util.FullDataFieldsError.
+func responseErrorOf(err error) (errors.ResponseError, bool) {
+  if _, ok := err.(util.FullDataFieldsError); ok {
+    return errors.InvalidFullDataFields, true
+  }
+
+  responseError, ok := err.(errors.ResponseError)
+  return responseError, ok
+}
@@ -0,0 +1,82 @@
+package kueski
+
+import (
+  "context"
+  "fmt"
+  "net/http"
+  "testing"
+  "time"
+
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski/errors"
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski/tracing"
+  "github.com/stretchr/testify/assert"
+)
+
+func TestEvaluateSpans(t *testing.T) {
+  spans := map[string]tracing.SpanData{}
+  traceparents := map[string]string{}
+  expiration := time.Now().Add(time.Hour).Unix()
+
+  client := NewClient("http://kueski.com", "Key", "Secret")
+  client.validator = func(curp, email string, fullData interface{}) error { return nil }
+  client.SetTracer(tracing.NewBasicTracer(func(data tracing.SpanData) {
+    if _, ok := spans[data.Name]; !ok {
+      spans[data.Name] = data
+    }
+  }))
+  client.requester = func(url string, headers map[string]string, body []byte) (*http.Response, error) {
+    traceparents[endpointName(url)] = headers[tracing.TraceParentHeader]
+
+    switch endpointName(url) {
+    case "authenticate":
+      return buildHTTPResponse(201, fmt.Sprintf(`{"token": "Token", "expiration": %d}`, expiration)), nil
+    case "lead-evaluation":
+      return buildHTTPResponse(201, fmt.Sprintf(`{ "curp": "%s", "email": "%s", "request_id": "%s", "status": "approved" }`, curp, email, requestID)), nil
+    }
+
+    return buildHTTPResponse(400, `{ "error": "Request not found" }`), nil
+  }
+
+  parent, _ := tracing.ParseTraceParent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", "")
+  ctx := tracing.ContextWithRemote(context.Background(), parent)
+  returned, err := client.EvaluateContext(ctx, curp, email, "data")
+
+  assert.Equal(t, requestID, returned)
+  assert.Equal(t, errors.RequestIDNotFound, err)
+
+  root := spans[StageEvaluate]
+  assert.Equal(t, parent.TraceID, root.Context.TraceID)
+  assert.Equal(t, parent.SpanID, root.ParentID)
+  assert.Equal(t, requestID, root.Attributes[requestIDAttribute])
+
+  for _, stage := range []string{StageValidation, StageLeadEvaluation, StageLeadData} {
+    assert.Equal(t, root.Context.SpanID, spans[stage].ParentID, stage)
+  }
+
+  assert.Equal(t, spans[StageLeadEvaluation].Context.SpanID, spans[StageAuthentication].ParentID)
+  assert.Equal(t, spans[StageAuthentication].Context.SpanID, spans["POST authenticate"].ParentID)
+  assert.Equal(t, spans[StageLeadData].Context.SpanID, spans["POST lead-data"].ParentID)
+
+  dataCall := spans["POST lead-data"]
+  assert.Equal(t, 400, dataCall.Attributes[statusCodeAttribute])
+  assert.Equal(t, "lead-data", dataCall.Attributes[endpointAttribute])
+  assert.Equal(t, int(errors.RequestIDNotFound), spans[StageLeadData].Attributes[errorAttribute])
+  assert.Equal(t, errors.RequestIDNotFound, spans[StageLeadData].Err)
+  assert.Equal(t, dataCall.Context.TraceParent(), traceparents["lead-data"])
+  assert.Equal(t, spans["POST lead-evaluation"].Context.TraceParent(), traceparents["lead-evaluation"])
+}
+
+func TestPropagationWithoutTracer(t *testing.T) {
+  client := Client{}
+  client.jwtProvider = &fakeTokenProvider{true}
+  client.requester = func(url string, headers map[string]string, body []byte) (*http.Response, error) {
+    assert.Equal(t, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", headers[tracing.TraceParentHeader])
+    assert.Equal(t, "vendor=value", headers[tracing.TraceStateHeader])
+    return nil, errors.UnableToMakeConnection
+  }
+
+  parent, _ := tracing.ParseTraceParent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", "vendor=value")
+  _, err := client.makeRequest(tracing.ContextWithRemote(context.Background(), parent), "path", []byte(""))
+
+  assert.Equal(t, errors.UnableToMakeConnection, err)
+}
@@ -0,0 +1,247 @@
+package tracing
+
+import (
+  "context"
+  "crypto/rand"
+  "encoding/hex"
+  "fmt"
+  "net/http"
+  "strings"
+  "sync"
+  "time"
+)
+
+// TraceParentHeader - W3C trace context parent header.
+// TraceStateHeader  - W3C trace context vendor state header.
+const (
+  TraceParentHeader string = "traceparent"
+  TraceStateHeader  string = "tracestate"
+)
+
+// SpanContext - Identifiers of a span, as propagated in the W3C trace context headers.
+type SpanContext struct {
+  TraceID    [16]byte
+  SpanID     [8]byte
+  Sampled    bool
+  TraceState string
+}
+
+// Span - A timed operation. Implementations must be safe to End more than once.
+type Span interface {
+  SetAttribute(key string, value interface{})
+  RecordError(err error)
+  End()
+  SpanContext() SpanContext
+}
+
+// Tracer - Starts spans, children of the span found in ctx (if any).
+// The returned context must carry the new span (see ContextWithSpan) so it is propagated.
+// Wrap an OpenTelemetry tracer to satisfy it and export the spans to any backend.
+type Tracer interface {
+  Start(ctx context.Context, name string) (context.Context, Span)
+}
+
+type spanKey struct{}
+type remoteKey struct{}
+
+// IsValid - Whether both trace and span IDs are set.
+func (sc SpanContext) IsValid() bool {
+  return sc.TraceID != [16]byte{} && sc.SpanID != [8]byte{}
+}
+
+// TraceParent - traceparent header value, version 00.
+func (sc SpanContext) TraceParent() string {
+  flags := "00"
+
+  if sc.Sampled {
+    flags = "01"
+  }
+
+  return fmt.Sprintf("00-%s-%s-%s", hex.EncodeToString(sc.TraceID[:]), hex.EncodeToString(sc.SpanID[:]), flags)
+}
+
+// ParseTraceParent - Builds a SpanContext from the traceparent and tracestate header values.
+func ParseTraceParent(traceparent, tracestate string) (SpanContext, error) {
+  var sc SpanContext
+  parts := strings.Split(strings.TrimSpace(traceparent), "-")
+
+  if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" || (parts[0] == "00" && len(parts) != 4) {
+    return sc, fmt.Errorf("tracing: malformed traceparent %q", traceparent)
+  }
+
+  traceID, traceErr := hex.DecodeString(parts[1])
+  spanID, spanErr := hex.DecodeString(parts[2])
+  flags, flagsErr := hex.DecodeString(parts[3])
+
+  if traceErr != nil || spanErr != nil || flagsErr != nil || len(traceID) != 16 || len(spanID) != 8 || len(flags) != 1 {
+    return sc, fmt.Errorf("tracing: malformed traceparent %q", traceparent)
+  }
+
+  copy(sc.TraceID[:], traceID)
+  copy(sc.SpanID[:], spanID)
+  sc.Sampled = flags[0]&1 == 1
+  sc.TraceState = tracestate
+
+  if !sc.IsValid() {
+    return SpanContext{}, fmt.Errorf("tracing: zero IDs in traceparent %q", traceparent)
+  }
+
+  return sc, nil
+}
+
+// ContextWithSpan - Returns a copy of ctx carrying span.
+func ContextWithSpan(ctx context.Context, span Span) context.Context {
+  return context.WithValue(ctx, spanKey{}, span)
+}
+
+// SpanFromContext - Span carried by ctx, or nil.
+func SpanFromContext(ctx context.Context) Span {
+  span, _ := ctx.Value(spanKey{}).(Span)
+  return span
+}
+
+// ContextWithRemote - Returns a copy of ctx carrying a parent span received from another process.
+func ContextWithRemote(ctx context.Context, sc SpanContext) context.Context {
+  return context.WithValue(ctx, remoteKey{}, sc)
+}
+
+// SpanContextFromContext - Context of the current span, or the remote parent if there is no local span.
+func SpanContextFromContext(ctx context.Context) SpanContext {
+  if span := SpanFromContext(ctx); span != nil {
+    return span.SpanContext()
+  }
+
+  sc, _ := ctx.Value(remoteKey{}).(SpanContext)
+  return sc
+}
+
+// Extract - Reads the W3C headers of an incoming request into ctx.
+func Extract(ctx context.Context, header http.Header) context.Context {
+  sc, err := ParseTraceParent(header.Get(TraceParentHeader), header.Get(TraceStateHeader))
+
+  if err != nil {
+    return ctx
+  }
+
+  return ContextWithRemote(ctx, sc)
+}
+
+// Inject - Writes the W3C headers of the span in ctx into headers.
+func Inject(ctx context.Context, headers map[string]string) {
+  sc := SpanContextFromContext(ctx)
+
+  if !sc.IsValid() {
+    return
+  }
+
+  headers[TraceParentHeader] = sc.TraceParent()
+
+  if sc.TraceState != "" {
+    headers[TraceStateHeader] = sc.TraceState
+  }
+}
+
+// NoopTracer - Tracer that records nothing but keeps propagating the parent span context.
+type NoopTracer struct{}
+
+// Start - Returns ctx unchanged and a span that does nothing.
+func (NoopTracer) Start(ctx context.Context, name string) (context.Context, Span) {
+  return ctx, noopSpan{SpanContextFromContext(ctx)}
+}
+
+type noopSpan struct {
+  sc SpanContext
+}
+
+func (noopSpan) SetAttribute(key string, value interface{}) {}
+func (noopSpan) RecordError(err error)                      {}
+func (noopSpan) End()                                       {}
+
+func (span noopSpan) SpanContext() SpanContext {
+  return span.sc
+}
+
+// SpanData - Snapshot of a finished span, handed to the exporter of a BasicTracer.
+type SpanData struct {
+  Name       string
+  Context    SpanContext
+  ParentID   [8]byte
+  Start      time.Time
+  End        time.Time
+  Attributes map[string]interface{}
+  Err        error
+}
+
+// BasicTracer - Minimal Tracer that generates W3C identifiers and hands finished spans to Export.
+type BasicTracer struct {
+  Export func(data SpanData)
+}
+
+// NewBasicTracer - BasicTracer constructor.
+func NewBasicTracer(export func(data SpanData)) *BasicTracer {
+  return &BasicTracer{export}
+}
+
+// Start - Starts a span, child of the span or remote parent in ctx. A new trace is started otherwise.
+func (tracer *BasicTracer) Start(ctx context.Context, name string) (context.Context, Span) {
+  parent := SpanContextFromContext(ctx)
+  span := &basicSpan{tracer: tracer}
+  span.data.Name = name
+  span.data.Start = time.Now()
+  span.data.Attributes = map[string]interface{}{}
+  span.data.Context.Sampled = true
+
+  if parent.IsValid() {
+    span.data.Context.TraceID = parent.TraceID
+    span.data.Context.Sampled = parent.Sampled
+    span.data.Context.TraceState = parent.TraceState
+    span.data.ParentID = parent.SpanID
+  } else {
+    rand.Read(span.data.Context.TraceID[:])
+  }
+
+  rand.Read(span.data.Context.SpanID[:])
+
+  return ContextWithSpan(ctx, span), span
+}
+
+type basicSpan struct {
+  tracer *BasicTracer
+  data   SpanData
+  ended  bool
+  sync.Mutex
+}
+
+func (span *basicSpan) SetAttribute(key string, value interface{}) {
+  span.Lock()
+  defer span.Unlock()
+  span.data.Attributes[key] = value
+}
+
+func (span *basicSpan) RecordError(err error) {
+  span.Lock()
+  defer span.Unlock()
+  span.data.Err = err
+}
+
+func (span *basicSpan) End() {
+  span.Lock()
+
+  if span.ended {
+    span.Unlock()
+    return
+  }
+
+  span.ended = true
+  span.data.End = time.Now()
+  data := span.data
+  span.Unlock()
+
+  if span.tracer.Export != nil {
+    span.tracer.Export(data)
+  }
+}
+
+func (span *basicSpan) SpanContext() SpanContext {
+  return span.data.Context
+}
@@ -0,0 +1,96 @@
+package tracing
+
+import (
+  "context"
+  "net/http"
+  "testing"
+
+  "github.com/stretchr/testify/assert"
+)
+
+var traceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+func TestParseTraceParent(t *testing.T) {
+  sc, err := ParseTraceParent(traceparent, "vendor=value")
+
+  assert.Nil(t, err)
+  assert.True(t, sc.IsValid())
+  assert.True(t, sc.Sampled)
+  assert.Equal(t, "vendor=value", sc.TraceState)
+  assert.Equal(t, traceparent, sc.TraceParent())
+
+  invalids := []string{
+    "",
+    "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7",
+    "ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+    "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra",
+    "00-4bf92f3577b34da6a3ce929d0e0e47-00f067aa0ba902b7-01",
+    "00-zzf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+    "00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+  }
+
+  for _, invalid := range invalids {
+    _, err := ParseTraceParent(invalid, "")
+    assert.NotNil(t, err, invalid)
+  }
+
+  future, err := ParseTraceParent("01"+traceparent[2:]+"-extra", "")
+  assert.Nil(t, err)
+  assert.Equal(t, traceparent, future.TraceParent())
+}
+
+func TestExtractAndInject(t *testing.T) {
+  header := http.Header{}
+  header.Set(TraceParentHeader, traceparent)
+  header.Set(TraceStateHeader, "vendor=value")
+
+  ctx := Extract(context.Background(), header)
+  headers := map[string]string{}
+  Inject(ctx, headers)
+
+  assert.Equal(t, traceparent, headers[TraceParentHeader])
+  assert.Equal(t, "vendor=value", headers[TraceStateHeader])
+
+  empty := map[string]string{}
+  Inject(Extract(context.Background(), http.Header{}), empty)
+  assert.Equal(t, 0, len(empty))
+}
+
+func TestNoopTracer(t *testing.T) {
+  parent, _ := ParseTraceParent(traceparent, "")
+  ctx, span := NoopTracer{}.Start(ContextWithRemote(context.Background(), parent), "noop")
+
+  span.SetAttribute("key", "value")
+  span.RecordError(nil)
+  span.End()
+
+  assert.Nil(t, SpanFromContext(ctx))
+  assert.Equal(t, parent, span.SpanContext())
+}
+
+func TestBasicTracer(t *testing.T) {
+  exported := []SpanData{}
+  tracer := NewBasicTracer(func(data SpanData) { exported = append(exported, data) })
+  parent, _ := ParseTraceParent(traceparent, "vendor=value")
+
+  ctx, root := tracer.Start(ContextWithRemote(context.Background(), parent), "root")
+  _, child := tracer.Start(ctx, "child")
+  child.SetAttribute("kueski.endpoint", "lead-data")
+  child.RecordError(context.Canceled)
+  child.End()
+  child.End()
+  root.End()
+
+  assert.Equal(t, 2, len(exported))
+  assert.Equal(t, "child", exported[0].Name)
+  assert.Equal(t, parent.TraceID, exported[0].Context.TraceID)
+  assert.Equal(t, root.SpanContext().SpanID, exported[0].ParentID)
+  assert.Equal(t, "lead-data", exported[0].Attributes["kueski.endpoint"])
+  assert.Equal(t, context.Canceled, exported[0].Err)
+  assert.Equal(t, parent.SpanID, exported[1].ParentID)
+  assert.Equal(t, "vendor=value", exported[1].Context.TraceState)
+
+  _, fresh := tracer.Start(context.Background(), "fresh")
+  assert.True(t, fresh.SpanContext().IsValid())
+  assert.NotEqual(t, parent.TraceID, fresh.SpanContext().TraceID)
+}
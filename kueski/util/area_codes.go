@@ -0,0 +1,90 @@
+package util
+
+// phoneAreaCodes - State of each area code of the national numbering plan, 2 digits for the
+// metropolitan areas of Mexico City, Guadalajara and Monterrey, 3 digits elsewhere.
+var phoneAreaCodes = map[string]string{
+  "449": "Aguascalientes", "458": "Aguascalientes", "465": "Aguascalientes", "495": "Aguascalientes",
+  "496": "Aguascalientes",
+  "646": "Baja California", "661": "Baja California", "664": "Baja California", "665": "Baja California",
+  "686": "Baja California",
+  "612": "Baja California Sur", "613": "Baja California Sur", "615": "Baja California Sur",
+  "624": "Baja California Sur",
+  "938": "Campeche", "981": "Campeche", "982": "Campeche", "996": "Campeche",
+  "916": "Chiapas", "919": "Chiapas", "961": "Chiapas", "962": "Chiapas", "963": "Chiapas", "964": "Chiapas",
+  "965": "Chiapas", "966": "Chiapas", "967": "Chiapas", "968": "Chiapas",
+  "614": "Chihuahua", "625": "Chihuahua", "626": "Chihuahua", "627": "Chihuahua", "628": "Chihuahua",
+  "629": "Chihuahua", "635": "Chihuahua", "636": "Chihuahua", "639": "Chihuahua", "648": "Chihuahua",
+  "649": "Chihuahua", "656": "Chihuahua", "659": "Chihuahua",
+  "55": "Ciudad de México", "56": "Ciudad de México",
+  "842": "Coahuila", "844": "Coahuila", "861": "Coahuila", "862": "Coahuila", "864": "Coahuila", "866": "Coahuila",
+  "869": "Coahuila", "872": "Coahuila", "877": "Coahuila", "878": "Coahuila",
+  "312": "Colima", "313": "Colima", "314": "Colima",
+  "618": "Durango", "671": "Durango", "674": "Durango", "675": "Durango", "676": "Durango", "677": "Durango",
+  "871": "Durango",
+  "592": "Estado de México", "593": "Estado de México", "594": "Estado de México", "595": "Estado de México",
+  "596": "Estado de México", "597": "Estado de México", "599": "Estado de México", "712": "Estado de México",
+  "713": "Estado de México", "714": "Estado de México", "716": "Estado de México", "717": "Estado de México",
+  "718": "Estado de México", "719": "Estado de México", "721": "Estado de México", "722": "Estado de México",
+  "723": "Estado de México", "724": "Estado de México", "725": "Estado de México", "726": "Estado de México",
+  "728": "Estado de México",
+  "411": "Guanajuato", "412": "Guanajuato", "413": "Guanajuato", "415": "Guanajuato", "417": "Guanajuato",
+  "418": "Guanajuato", "421": "Guanajuato", "428": "Guanajuato", "429": "Guanajuato", "438": "Guanajuato",
+  "445": "Guanajuato", "456": "Guanajuato", "461": "Guanajuato", "462": "Guanajuato", "464": "Guanajuato",
+  "466": "Guanajuato", "468": "Guanajuato", "469": "Guanajuato", "472": "Guanajuato", "473": "Guanajuato",
+  "476": "Guanajuato", "477": "Guanajuato", "479": "Guanajuato",
+  "733": "Guerrero", "736": "Guerrero", "741": "Guerrero", "742": "Guerrero", "744": "Guerrero", "745": "Guerrero",
+  "747": "Guerrero", "754": "Guerrero", "755": "Guerrero", "756": "Guerrero", "757": "Guerrero", "758": "Guerrero",
+  "762": "Guerrero", "767": "Guerrero", "781": "Guerrero",
+  "738": "Hidalgo", "748": "Hidalgo", "759": "Hidalgo", "761": "Hidalgo", "763": "Hidalgo", "771": "Hidalgo",
+  "772": "Hidalgo", "773": "Hidalgo", "774": "Hidalgo", "775": "Hidalgo", "778": "Hidalgo", "791": "Hidalgo",
+  "33": "Jalisco", "315": "Jalisco", "316": "Jalisco", "317": "Jalisco", "322": "Jalisco", "326": "Jalisco",
+  "341": "Jalisco", "342": "Jalisco", "343": "Jalisco", "344": "Jalisco", "345": "Jalisco", "346": "Jalisco",
+  "347": "Jalisco", "348": "Jalisco", "349": "Jalisco", "357": "Jalisco", "358": "Jalisco", "371": "Jalisco",
+  "372": "Jalisco", "373": "Jalisco", "374": "Jalisco", "375": "Jalisco", "376": "Jalisco", "377": "Jalisco",
+  "378": "Jalisco", "382": "Jalisco", "384": "Jalisco", "385": "Jalisco", "386": "Jalisco", "387": "Jalisco",
+  "388": "Jalisco", "391": "Jalisco", "392": "Jalisco", "393": "Jalisco", "395": "Jalisco", "474": "Jalisco",
+  "351": "Michoacán", "352": "Michoacán", "353": "Michoacán", "354": "Michoacán", "355": "Michoacán",
+  "356": "Michoacán", "359": "Michoacán", "381": "Michoacán", "383": "Michoacán", "423": "Michoacán",
+  "424": "Michoacán", "425": "Michoacán", "426": "Michoacán", "434": "Michoacán", "435": "Michoacán",
+  "436": "Michoacán", "443": "Michoacán", "447": "Michoacán", "451": "Michoacán", "452": "Michoacán",
+  "453": "Michoacán", "454": "Michoacán", "459": "Michoacán", "711": "Michoacán", "715": "Michoacán",
+  "753": "Michoacán", "786": "Michoacán",
+  "731": "Morelos", "734": "Morelos", "735": "Morelos", "739": "Morelos", "751": "Morelos", "769": "Morelos",
+  "777": "Morelos",
+  "311": "Nayarit", "319": "Nayarit", "323": "Nayarit", "324": "Nayarit", "325": "Nayarit", "327": "Nayarit",
+  "389": "Nayarit",
+  "81": "Nuevo León", "821": "Nuevo León", "823": "Nuevo León", "824": "Nuevo León", "825": "Nuevo León",
+  "826": "Nuevo León", "828": "Nuevo León", "829": "Nuevo León", "873": "Nuevo León", "892": "Nuevo León",
+  "287": "Oaxaca", "951": "Oaxaca", "953": "Oaxaca", "954": "Oaxaca", "958": "Oaxaca", "971": "Oaxaca",
+  "972": "Oaxaca", "994": "Oaxaca", "995": "Oaxaca",
+  "221": "Puebla", "222": "Puebla", "223": "Puebla", "224": "Puebla", "227": "Puebla", "231": "Puebla",
+  "232": "Puebla", "233": "Puebla", "236": "Puebla", "237": "Puebla", "238": "Puebla", "243": "Puebla",
+  "244": "Puebla", "248": "Puebla", "249": "Puebla", "275": "Puebla", "764": "Puebla", "776": "Puebla",
+  "797": "Puebla",
+  "414": "Querétaro", "419": "Querétaro", "427": "Querétaro", "441": "Querétaro", "442": "Querétaro",
+  "448": "Querétaro",
+  "983": "Quintana Roo", "984": "Quintana Roo", "987": "Quintana Roo", "998": "Quintana Roo",
+  "444": "San Luis Potosí", "481": "San Luis Potosí", "482": "San Luis Potosí", "483": "San Luis Potosí",
+  "485": "San Luis Potosí", "486": "San Luis Potosí", "487": "San Luis Potosí", "488": "San Luis Potosí",
+  "489": "San Luis Potosí",
+  "667": "Sinaloa", "668": "Sinaloa", "669": "Sinaloa", "672": "Sinaloa", "673": "Sinaloa", "687": "Sinaloa",
+  "694": "Sinaloa", "696": "Sinaloa", "697": "Sinaloa", "698": "Sinaloa",
+  "622": "Sonora", "623": "Sonora", "631": "Sonora", "632": "Sonora", "633": "Sonora", "634": "Sonora",
+  "637": "Sonora", "638": "Sonora", "641": "Sonora", "642": "Sonora", "643": "Sonora", "644": "Sonora",
+  "645": "Sonora", "647": "Sonora", "651": "Sonora", "653": "Sonora", "662": "Sonora",
+  "913": "Tabasco", "914": "Tabasco", "917": "Tabasco", "923": "Tabasco", "932": "Tabasco", "933": "Tabasco",
+  "934": "Tabasco", "936": "Tabasco", "937": "Tabasco", "993": "Tabasco",
+  "831": "Tamaulipas", "832": "Tamaulipas", "833": "Tamaulipas", "834": "Tamaulipas", "835": "Tamaulipas",
+  "836": "Tamaulipas", "841": "Tamaulipas", "867": "Tamaulipas", "868": "Tamaulipas", "891": "Tamaulipas",
+  "894": "Tamaulipas", "897": "Tamaulipas", "899": "Tamaulipas",
+  "241": "Tlaxcala", "246": "Tlaxcala", "276": "Tlaxcala",
+  "225": "Veracruz", "226": "Veracruz", "228": "Veracruz", "229": "Veracruz", "235": "Veracruz", "271": "Veracruz",
+  "272": "Veracruz", "273": "Veracruz", "274": "Veracruz", "278": "Veracruz", "279": "Veracruz", "282": "Veracruz",
+  "283": "Veracruz", "284": "Veracruz", "285": "Veracruz", "288": "Veracruz", "294": "Veracruz", "296": "Veracruz",
+  "297": "Veracruz", "782": "Veracruz", "783": "Veracruz", "784": "Veracruz", "785": "Veracruz", "789": "Veracruz",
+  "846": "Veracruz", "921": "Veracruz", "922": "Veracruz", "924": "Veracruz",
+  "969": "Yucatán", "985": "Yucatán", "986": "Yucatán", "988": "Yucatán", "991": "Yucatán", "997": "Yucatán",
+  "999": "Yucatán",
+  "457": "Zacatecas", "463": "Zacatecas", "467": "Zacatecas", "478": "Zacatecas", "492": "Zacatecas",
+  "493": "Zacatecas", "494": "Zacatecas", "498": "Zacatecas", "499": "Zacatecas",
+}
@@ -1,7 +1,9 @@
 package util
 
 import (
+  "crypto/hmac"
   "crypto/md5"
+  "crypto/sha1"
   "encoding/base64"
   "strings"
   "time"
@@ -31,3 +33,10 @@ func ContentMD5(content string) string {
 func HTTPDate(date time.Time) string {
   return date.In(gmtLocation).Format(time.RFC1123)
 }
+
+// Sign - HMAC-SHA1 signature of the canonical string, base64 encoded.
+func Sign(secretKey, canonical string) string {
+  h := hmac.New(sha1.New, []byte(secretKey))
+  h.Write([]byte(canonical))
+  return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
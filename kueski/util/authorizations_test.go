@@ -23,3 +23,7 @@ func TestHTTPDate(t *testing.T) {
   now, _ := time.Parse(time.RFC1123, str)
   assert.Equal(t, str, HTTPDate(now))
 }
+
+func TestSign(t *testing.T) {
+  assert.Equal(t, "1pbKbWCwwA/cOlxtE9+9L4wp4Bc=", Sign("secretkey", "canonical"))
+}
@@ -0,0 +1,95 @@
+package util
+
+// clabeBanks - Banks and financial institutions by the ABM code of the first three CLABE digits.
+var clabeBanks = map[string]string{
+  "002": "BANAMEX",
+  "006": "BANCOMEXT",
+  "009": "BANOBRAS",
+  "012": "BBVA MEXICO",
+  "014": "SANTANDER",
+  "019": "BANJERCITO",
+  "021": "HSBC",
+  "030": "BAJIO",
+  "036": "INBURSA",
+  "042": "MIFEL",
+  "044": "SCOTIABANK",
+  "058": "BANREGIO",
+  "059": "INVEX",
+  "060": "BANSI",
+  "062": "AFIRME",
+  "072": "BANORTE",
+  "106": "BANK OF AMERICA",
+  "108": "MUFG",
+  "110": "JP MORGAN",
+  "112": "BMONEX",
+  "113": "VE POR MAS",
+  "127": "AZTECA",
+  "128": "AUTOFIN",
+  "129": "BARCLAYS",
+  "130": "COMPARTAMOS",
+  "132": "MULTIVA BANCO",
+  "133": "ACTINVER",
+  "135": "NAFIN",
+  "136": "INTERCAM BANCO",
+  "137": "BANCOPPEL",
+  "138": "ABC CAPITAL",
+  "140": "CONSUBANCO",
+  "141": "VOLKSWAGEN",
+  "143": "CIBANCO",
+  "145": "BBASE",
+  "147": "BANKAOOL",
+  "148": "PAGATODO",
+  "150": "INMOBILIARIO",
+  "151": "DONDE",
+  "152": "BANCREA",
+  "154": "BANCO COVALTO",
+  "155": "ICBC",
+  "156": "SABADELL",
+  "157": "SHINHAN",
+  "158": "MIZUHO BANK",
+  "159": "BANK OF CHINA",
+  "160": "BANCO S3",
+  "166": "BANCO DEL BIENESTAR",
+  "168": "HIPOTECARIA FEDERAL",
+  "600": "MONEXCB",
+  "601": "GBM",
+  "602": "MASARI",
+  "605": "VALUE",
+  "608": "VECTOR",
+  "610": "B&B",
+  "616": "FINAMEX",
+  "617": "VALMEX",
+  "620": "PROFUTURO",
+  "630": "CB INTERCAM",
+  "631": "CI BOLSA",
+  "634": "FINCOMUN",
+  "638": "NU MEXICO",
+  "646": "STP",
+  "652": "CREDICAPITAL",
+  "653": "KUSPIT",
+  "656": "UNAGRA",
+  "659": "ASP INTEGRA OPC",
+  "670": "LIBERTAD",
+  "677": "CAJA POP MEXICA",
+  "680": "CRISTOBAL COLON",
+  "683": "CAJA TELEFONIST",
+  "684": "TRANSFER",
+  "685": "FONDO (FIRA)",
+  "686": "INVERCAP",
+  "689": "FOMPED",
+  "699": "FONDEADORA",
+  "703": "TESORED",
+  "706": "ARCUS",
+  "710": "NVIO",
+  "722": "MERCADO PAGO",
+  "723": "CUENCA",
+  "728": "SPIN BY OXXO",
+  "902": "INDEVAL",
+}
+
+// clabePlazas - Names of the main plazas by the code of the CLABE digits 4 to 6.
+var clabePlazas = map[string]string{
+  "180": "Ciudad de México",
+  "320": "Guadalajara",
+  "580": "Monterrey",
+}
@@ -0,0 +1,146 @@
+package util
+
+import (
+  "encoding/json"
+  "regexp"
+  "strconv"
+  "strings"
+)
+
+// CardError - Rule of the card number format a card number breaks.
+type CardError string
+
+// CardFormat  - It is not made of 12 to 19 digits, optionally grouped with spaces or dashes.
+// CardNetwork - The BIN prefix is not of a supported network, or the length is not valid for it.
+// CardLuhn    - The Luhn check digit does not match.
+const (
+  CardFormat  CardError = "format"
+  CardNetwork CardError = "network"
+  CardLuhn    CardError = "check digit"
+)
+
+func (err CardError) Error() string {
+  return "invalid card number " + string(err)
+}
+
+// CardInfo - Network of a card and its number without separators.
+type CardInfo struct {
+  Network string
+  Number  string
+}
+
+// cardNetwork - BIN prefixes of a network, as inclusive ranges of equal length, and the valid number lengths.
+type cardNetwork struct {
+  name    string
+  ranges  [][2]string
+  lengths []int
+}
+
+// cardNetworks - Networks of the debit cards issued in Mexico.
+var cardNetworks = []cardNetwork{
+  {"Carnet", [][2]string{{"286900", "286900"}, {"502275", "502275"}, {"506199", "506499"}, {"639484", "639484"}, {"639559", "639559"}}, []int{16}},
+  {"Visa", [][2]string{{"4", "4"}}, []int{13, 16, 19}},
+  {"Mastercard", [][2]string{{"51", "55"}, {"2221", "2720"}}, []int{16}},
+}
+
+var cardRegex = regexp.MustCompile(`^\d{12,19}$`)
+
+// CheckCardNumber - Debit card number verification: BIN prefix and length of a supported network, and Luhn check digit.
+// Spaces and dashes are ignored. Returns the CardError of the first rule broken, nil if valid.
+func CheckCardNumber(number string) error {
+  _, err := ParseCardNumber(number)
+  return err
+}
+
+// ValidateCardNumber - Debit card number validation, see CheckCardNumber for the rule broken.
+func ValidateCardNumber(number string) bool {
+  return CheckCardNumber(number) == nil
+}
+
+// ParseCardNumber - Network and digits of a card number verified by CheckCardNumber.
+func ParseCardNumber(number string) (CardInfo, error) {
+  digits := strings.NewReplacer(" ", "", "-", "").Replace(number)
+
+  if !cardRegex.MatchString(digits) {
+    return CardInfo{}, CardFormat
+  }
+
+  network, ok := cardNetworkOf(digits)
+
+  if !ok {
+    return CardInfo{}, CardNetwork
+  }
+
+  if !luhn(digits) {
+    return CardInfo{}, CardLuhn
+  }
+
+  return CardInfo{network.name, digits}, nil
+}
+
+// CardNumber - Debit card number typed field for full data structs. ValidateFullData rejects the full data with
+// InvalidCardNumber when it is set and invalid. An empty CardNumber is valid, for optional fields.
+type CardNumber string
+
+// MarshalJSON - Marshals a valid card number without separators, any other as it is.
+func (number CardNumber) MarshalJSON() ([]byte, error) {
+  if info, err := ParseCardNumber(string(number)); err == nil {
+    return json.Marshal(info.Number)
+  }
+
+  return json.Marshal(string(number))
+}
+
+func (number CardNumber) check() error {
+  if number == "" {
+    return nil
+  }
+
+  return CheckCardNumber(string(number))
+}
+
+// cardNetworkOf - Network whose BIN prefixes and lengths match the number.
+func cardNetworkOf(digits string) (cardNetwork, bool) {
+  for _, network := range cardNetworks {
+    for _, prefixes := range network.ranges {
+      prefix, _ := strconv.Atoi(digits[:len(prefixes[0])])
+      from, _ := strconv.Atoi(prefixes[0])
+      to, _ := strconv.Atoi(prefixes[1])
+
+      if prefix < from || prefix > to {
+        continue
+      }
+
+      for _, length := range network.lengths {
+        if len(digits) == length {
+          return network, true
+        }
+      }
+
+      return cardNetwork{}, false
+    }
+  }
+
+  return cardNetwork{}, false
+}
+
+// luhn - The last digit is the Luhn check digit of the others.
+func luhn(digits string) bool {
+  sum := 0
+
+  for i := range digits {
+    digit := int(digits[len(digits)-1-i] - '0')
+
+    if i%2 == 1 {
+      digit *= 2
+
+      if digit > 9 {
+        digit -= 9
+      }
+    }
+
+    sum += digit
+  }
+
+  return sum%10 == 0
+}
@@ -0,0 +1,45 @@
+package util
+
+import (
+  "encoding/json"
+  "testing"
+
+  "github.com/stretchr/testify/assert"
+)
+
+func TestCheckCardNumber(t *testing.T) {
+  assert.Nil(t, CheckCardNumber("4111111111111111"))
+  assert.Nil(t, CheckCardNumber("5555-5555-5555-4444"))
+  assert.Nil(t, CheckCardNumber("2221 0000 0000 0009"))
+  assert.True(t, ValidateCardNumber("5062210000000009"))
+  assert.Equal(t, CardFormat, CheckCardNumber("4111"))
+  assert.Equal(t, CardFormat, CheckCardNumber("4111 1111 1111 111A"))
+  assert.Equal(t, CardNetwork, CheckCardNumber("378282246310005"))
+  assert.Equal(t, CardNetwork, CheckCardNumber("6011000000000004"))
+  assert.Equal(t, CardNetwork, CheckCardNumber("41111111111111111"))
+  assert.Equal(t, CardLuhn, CheckCardNumber("4111111111111112"))
+  assert.EqualError(t, CheckCardNumber("4111111111111112"), "invalid card number check digit")
+}
+
+func TestParseCardNumber(t *testing.T) {
+  info, err := ParseCardNumber("5062 2100 0000 0009")
+
+  assert.Nil(t, err)
+  assert.Equal(t, CardInfo{"Carnet", "5062210000000009"}, info)
+
+  info, err = ParseCardNumber("5555 5555 5555 4444")
+
+  assert.Nil(t, err)
+  assert.Equal(t, "Mastercard", info.Network)
+}
+
+func TestCardNumberMarshal(t *testing.T) {
+  blob, err := json.Marshal(CardNumber("4111-1111-1111-1111"))
+
+  assert.Nil(t, err)
+  assert.Equal(t, `"4111111111111111"`, string(blob))
+
+  blob, err = json.Marshal(CardNumber("4111-1111-1111-1112"))
+  assert.Nil(t, err)
+  assert.Equal(t, `"4111-1111-1111-1112"`, string(blob))
+}
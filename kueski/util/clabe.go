@@ -0,0 +1,96 @@
+package util
+
+import (
+  "regexp"
+)
+
+// ClabeError - Rule of the CLABE format a CLABE breaks.
+type ClabeError string
+
+// ClabeFormat     - It is not 18 digits.
+// ClabeBank       - The bank code is not in the bank table.
+// ClabeCheckDigit - The weighted check digit does not match.
+const (
+  ClabeFormat     ClabeError = "format"
+  ClabeBank       ClabeError = "bank"
+  ClabeCheckDigit ClabeError = "check digit"
+)
+
+func (err ClabeError) Error() string {
+  return "invalid CLABE " + string(err)
+}
+
+// ClabeInfo - Data encoded in a CLABE. Plaza is empty for the plazas missing in the table.
+type ClabeInfo struct {
+  BankCode  string
+  Bank      string
+  PlazaCode string
+  Plaza     string
+  Account   string
+}
+
+var clabeRegex = regexp.MustCompile(`^\d{18}$`)
+
+// clabeWeights - Weight of each of the first 17 CLABE digits in the check digit computation.
+var clabeWeights = [17]int{3, 7, 1, 3, 7, 1, 3, 7, 1, 3, 7, 1, 3, 7, 1, 3, 7}
+
+// CheckClabe - CLABE verification: 18 digits, known bank code and check digit.
+// Returns the ClabeError of the first rule broken, nil if valid.
+func CheckClabe(clabe string) error {
+  if !clabeRegex.MatchString(clabe) {
+    return ClabeFormat
+  }
+
+  if _, ok := clabeBanks[clabe[:3]]; !ok {
+    return ClabeBank
+  }
+
+  if clabeCheckDigit(clabe[:17]) != clabe[17] {
+    return ClabeCheckDigit
+  }
+
+  return nil
+}
+
+// ValidateClabe - CLABE validation, see CheckClabe for the rule broken.
+func ValidateClabe(clabe string) bool {
+  return CheckClabe(clabe) == nil
+}
+
+// ParseClabe - Decodes a CLABE verified by CheckClabe: bank, plaza and account number.
+func ParseClabe(clabe string) (ClabeInfo, error) {
+  if err := CheckClabe(clabe); err != nil {
+    return ClabeInfo{}, err
+  }
+
+  return ClabeInfo{
+    BankCode:  clabe[:3],
+    Bank:      clabeBanks[clabe[:3]],
+    PlazaCode: clabe[3:6],
+    Plaza:     clabePlazas[clabe[3:6]],
+    Account:   clabe[6:17],
+  }, nil
+}
+
+// Clabe - CLABE typed field for full data structs. ValidateFullData rejects the full data with InvalidClabe
+// when it is set and invalid. An empty Clabe is valid, for optional fields.
+type Clabe string
+
+func (clabe Clabe) check() error {
+  if clabe == "" {
+    return nil
+  }
+
+  return CheckClabe(string(clabe))
+}
+
+// clabeCheckDigit - Check digit of the first 17 digits of a CLABE.
+func clabeCheckDigit(clabe17 string) byte {
+  sum := 0
+
+  for i, weight := range clabeWeights {
+    sum += int(clabe17[i]-'0') * weight % 10
+  }
+
+  return byte('0' + (10-sum%10)%10)
+}
@@ -0,0 +1,46 @@
+package util
+
+import (
+  "encoding/json"
+  "testing"
+
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski/errors"
+  "github.com/stretchr/testify/assert"
+)
+
+func TestCheckClabe(t *testing.T) {
+  assert.Nil(t, CheckClabe("002180000118359710"))
+  assert.True(t, ValidateClabe("646180000000000009"))
+  assert.Equal(t, ClabeFormat, CheckClabe("00218000011835971"))
+  assert.Equal(t, ClabeFormat, CheckClabe("00218000011835971A"))
+  assert.Equal(t, ClabeBank, CheckClabe("999180000000000002"))
+  assert.Equal(t, ClabeCheckDigit, CheckClabe("002180000118359711"))
+  assert.EqualError(t, CheckClabe("002180000118359711"), "invalid CLABE check digit")
+}
+
+func TestParseClabe(t *testing.T) {
+  info, err := ParseClabe("012180001234567899")
+
+  assert.Nil(t, err)
+  assert.Equal(t, ClabeInfo{"012", "BBVA MEXICO", "180", "Ciudad de México", "00123456789"}, info)
+
+  _, err = ParseClabe("012180001234567890")
+  assert.Equal(t, ClabeCheckDigit, err)
+}
+
+type DisbursementFullData struct {
+  Clabe Clabe      `json:"clabe,omitempty"`
+  Card  CardNumber `json:"card,omitempty"`
+}
+
+func TestDisbursementFullDataValidation(t *testing.T) {
+  assert.Nil(t, ValidateFullData(DisbursementFullData{"002180000118359710", "4111 1111 1111 1111"}))
+  assert.Nil(t, ValidateFullData(DisbursementFullData{}))
+  assert.Equal(t, errors.InvalidClabe, ValidateFullData(DisbursementFullData{Clabe: "002180000118359711"}))
+  assert.Equal(t, errors.InvalidCardNumber, ValidateFullData(DisbursementFullData{Card: "4111 1111 1111 1112"}))
+  assert.Equal(t, errors.InvalidClabe, ValidateFullData([]DisbursementFullData{{}, {Clabe: "0021800001183597"}}))
+
+  blob, err := json.Marshal(DisbursementFullData{"002180000118359711", "4111 1111 1111 1112"})
+  assert.Nil(t, err)
+  assert.Equal(t, `{"clabe":"002180000118359711","card":"4111 1111 1111 1112"}`, string(blob))
+}
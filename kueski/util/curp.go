@@ -0,0 +1,183 @@
+package util
+
+import (
+  "regexp"
+  "strconv"
+  "strings"
+  "time"
+  "unicode/utf8"
+)
+
+// CurpError - Rule of the CURP format a CURP breaks.
+type CurpError string
+
+// CurpLength     - It is not 18 characters long.
+// CurpFormat     - Letters and digits are not where they belong.
+// CurpBirthDate  - The birth date is not a calendar date.
+// CurpSex        - The sex is not H, M or X.
+// CurpState      - The state code is not in the RENAPO table.
+// CurpConsonants - The internal consonants of the surnames and name are not consonants.
+// CurpCheckDigit - The verification digit does not match.
+const (
+  CurpLength     CurpError = "length"
+  CurpFormat     CurpError = "format"
+  CurpBirthDate  CurpError = "birth date"
+  CurpSex        CurpError = "sex"
+  CurpState      CurpError = "state"
+  CurpConsonants CurpError = "internal consonants"
+  CurpCheckDigit CurpError = "check digit"
+)
+
+func (err CurpError) Error() string {
+  return "invalid CURP " + string(err)
+}
+
+// curpStates - RENAPO state codes. NE stands for born abroad.
+var curpStates = map[string]string{
+  "AS": "Aguascalientes",
+  "BC": "Baja California",
+  "BS": "Baja California Sur",
+  "CC": "Campeche",
+  "CL": "Coahuila",
+  "CM": "Colima",
+  "CS": "Chiapas",
+  "CH": "Chihuahua",
+  "DF": "Ciudad de México",
+  "DG": "Durango",
+  "GT": "Guanajuato",
+  "GR": "Guerrero",
+  "HG": "Hidalgo",
+  "JC": "Jalisco",
+  "MC": "Estado de México",
+  "MN": "Michoacán",
+  "MS": "Morelos",
+  "NT": "Nayarit",
+  "NL": "Nuevo León",
+  "OC": "Oaxaca",
+  "PL": "Puebla",
+  "QT": "Querétaro",
+  "QR": "Quintana Roo",
+  "SP": "San Luis Potosí",
+  "SL": "Sinaloa",
+  "SR": "Sonora",
+  "TC": "Tabasco",
+  "TS": "Tamaulipas",
+  "TL": "Tlaxcala",
+  "VZ": "Veracruz",
+  "YN": "Yucatán",
+  "ZS": "Zacatecas",
+  "NE": "Nacido en el extranjero",
+}
+
+// curpAlphabet - Value of each character in the check digit computation.
+const curpAlphabet string = "0123456789ABCDEFGHIJKLMNÑOPQRSTUVWXYZ"
+
+var (
+  curpShapeRegex      = regexp.MustCompile(`^[A-Z]{4}\d{6}[A-Z]{6}[0-9A-Z]\d$`)
+  curpConsonantsRegex = regexp.MustCompile(`^[B-DF-HJ-NP-TV-Z]{3}$`)
+)
+
+// CheckCurp - Full CURP verification: format, birth date, sex, state code, internal consonants and check digit.
+// Returns the CurpError of the first rule broken, nil if valid.
+func CheckCurp(curp string) error {
+  if len(curp) != 18 {
+    return CurpLength
+  }
+
+  if !curpShapeRegex.MatchString(curp) {
+    return CurpFormat
+  }
+
+  if _, ok := curpBirthDate(curp); !ok {
+    return CurpBirthDate
+  }
+
+  if !strings.Contains("HMX", curp[10:11]) {
+    return CurpSex
+  }
+
+  if _, ok := curpStates[curp[11:13]]; !ok {
+    return CurpState
+  }
+
+  if !curpConsonantsRegex.MatchString(curp[13:16]) {
+    return CurpConsonants
+  }
+
+  if curpCheckDigit(curp[:17]) != curp[17] {
+    return CurpCheckDigit
+  }
+
+  return nil
+}
+
+// curpCheckDigit - Verification digit (18th character) of the first 17 characters of a CURP.
+func curpCheckDigit(curp17 string) byte {
+  sum := 0
+
+  for i, char := range []rune(curp17) {
+    sum += utf8.RuneCountInString(curpAlphabet[:strings.IndexRune(curpAlphabet, char)]) * (18 - i)
+  }
+
+  return byte('0' + (10-sum%10)%10)
+}
+
+// curpBirthDate - Birth date of a well formed CURP. The 17th character tells the century:
+// a digit for the 1900s, a letter for the 2000s. Returns false if it is not a calendar date.
+func curpBirthDate(curp string) (time.Time, bool) {
+  year, _ := strconv.Atoi(curp[4:6])
+  month, _ := strconv.Atoi(curp[6:8])
+  day, _ := strconv.Atoi(curp[8:10])
+
+  if curp[16] >= '0' && curp[16] <= '9' {
+    year += 1900
+  } else {
+    year += 2000
+  }
+
+  date := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+  return date, date.Year() == year && int(date.Month()) == month && date.Day() == day
+}
+
+// CurpInfo - Data encoded in a CURP.
+// Sex         - H, M or X.
+// Age         - Whole years at the time given to ParseCurp.
+// BornAbroad  - The state code is NE.
+type CurpInfo struct {
+  BirthDate  time.Time
+  Age        int
+  Sex        string
+  StateCode  string
+  StateName  string
+  BornAbroad bool
+}
+
+// ParseCurp - Decodes a CURP verified by CheckCurp, computing the age at the given time.
+func ParseCurp(curp string, at time.Time) (CurpInfo, error) {
+  if err := CheckCurp(curp); err != nil {
+    return CurpInfo{}, err
+  }
+
+  birthDate, _ := curpBirthDate(curp)
+  stateCode := curp[11:13]
+
+  return CurpInfo{
+    BirthDate:  birthDate,
+    Age:        Age(birthDate, at),
+    Sex:        curp[10:11],
+    StateCode:  stateCode,
+    StateName:  curpStates[stateCode],
+    BornAbroad: stateCode == "NE",
+  }, nil
+}
+
+// Age - Whole years from birth to at. Those born on February 29 turn a year older on March 1.
+func Age(birthDate, at time.Time) int {
+  years := at.Year() - birthDate.Year()
+
+  if at.Month() < birthDate.Month() || (at.Month() == birthDate.Month() && at.Day() < birthDate.Day()) {
+    years--
+  }
+
+  return years
+}
@@ -0,0 +1,77 @@
+package util
+
+import (
+  "math/rand"
+  "sort"
+  "strings"
+  "time"
+)
+
+// GenerateCurp - CURP of the applicant born in the state (a RENAPO code, NE if born abroad), following
+// the RENAPO rules. The 17th character, assigned by RENAPO to tell homonyms apart, is 0 for the 1900s
+// and A for the 2000s, so the result may differ from the actual CURP in that character and the check digit.
+func GenerateCurp(applicant Applicant, state string) (string, error) {
+  year := applicant.BirthDate.Year()
+
+  if applicant.BirthDate.IsZero() || year < 1900 || year > 2099 {
+    return "", CurpBirthDate
+  }
+
+  sex := strings.ToUpper(applicant.Sex)
+
+  if len(sex) != 1 || !strings.Contains("HMX", sex) {
+    return "", CurpSex
+  }
+
+  state = strings.ToUpper(state)
+
+  if _, ok := curpStates[state]; !ok {
+    return "", CurpState
+  }
+
+  homoclave := "0"
+
+  if year >= 2000 {
+    homoclave = "A"
+  }
+
+  curp := curpNameLetters(applicant) + applicant.BirthDate.Format("060102") + sex + state +
+    curpNameConsonants(applicant) + homoclave
+  return curp + string(curpCheckDigit(curp)), nil
+}
+
+// curpLetters    - Letters a CURP name character can take.
+// curpConsonants - Letters an internal consonant character can take.
+const (
+  curpLetters    string = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+  curpConsonants string = "BCDFGHJKLMNPQRSTVWXYZ"
+)
+
+// RandomCurp - Random CURP passing CheckCurp, of someone born between 1930 and 2009. Meant for test data.
+func RandomCurp(random *rand.Rand) string {
+  states := make([]string, 0, len(curpStates))
+
+  for code := range curpStates {
+    states = append(states, code)
+  }
+
+  // Map iteration order is random, sort for the result to depend on random only.
+  sort.Strings(states)
+
+  pick := func(chars string) byte { return chars[random.Intn(len(chars))] }
+  birthDate := time.Date(1930, 1, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, random.Intn(80*365))
+
+  curp := []byte{pick(curpLetters), pick(curpLetters), pick(curpLetters), pick(curpLetters)}
+  curp = append(curp, birthDate.Format("060102")...)
+  curp = append(curp, pick("HM"))
+  curp = append(curp, states[random.Intn(len(states))]...)
+  curp = append(curp, pick(curpConsonants), pick(curpConsonants), pick(curpConsonants))
+
+  if birthDate.Year() < 2000 {
+    curp = append(curp, pick("0123456789"))
+  } else {
+    curp = append(curp, pick(curpLetters))
+  }
+
+  return string(curp) + string(curpCheckDigit(string(curp)))
+}
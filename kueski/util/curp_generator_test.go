@@ -0,0 +1,67 @@
+package util
+
+import (
+  "math/rand"
+  "testing"
+  "time"
+
+  "github.com/stretchr/testify/assert"
+)
+
+func TestGenerateCurp(t *testing.T) {
+  applicant := Applicant{"Juan", "Pérez", "López", time.Date(1990, 5, 15, 0, 0, 0, 0, time.UTC), "H"}
+  curp, err := GenerateCurp(applicant, "DF")
+
+  assert.Nil(t, err)
+  assert.Equal(t, "PELJ900515HDFRPN07", curp)
+
+  applicant = Applicant{"María de los Ángeles", "García", "Muñoz", time.Date(2005, 3, 13, 0, 0, 0, 0, time.UTC), "m"}
+  curp, err = GenerateCurp(applicant, "jc")
+
+  assert.Nil(t, err)
+  assert.Equal(t, "GAMA050313MJCRXNA", curp[:17])
+  assert.Nil(t, CheckCurp(curp))
+
+  _, err = GenerateCurp(applicant, "XX")
+  assert.Equal(t, CurpState, err)
+
+  applicant.Sex = "F"
+  _, err = GenerateCurp(applicant, "JC")
+  assert.Equal(t, CurpSex, err)
+
+  applicant.BirthDate = time.Time{}
+  _, err = GenerateCurp(applicant, "JC")
+  assert.Equal(t, CurpBirthDate, err)
+}
+
+func TestGeneratedCurpsMatchTheirApplicant(t *testing.T) {
+  applicants := []Applicant{
+    {"José Luis", "de la Fuente", "Ruiz", time.Date(1975, 12, 1, 0, 0, 0, 0, time.UTC), "H"},
+    {"Ana", "Castro", "Casas", time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC), "M"},
+    {"Ma. Luisa", "Ñúñez", "", time.Date(1960, 2, 29, 0, 0, 0, 0, time.UTC), "M"},
+  }
+
+  for _, applicant := range applicants {
+    curp, err := GenerateCurp(applicant, "NE")
+    assert.Nil(t, err)
+
+    match, err := MatchCurp(curp, applicant)
+
+    assert.Nil(t, err)
+    assert.Equal(t, CurpMatch{1, []int{}}, match, curp)
+  }
+}
+
+func TestRandomCurp(t *testing.T) {
+  random := rand.New(rand.NewSource(1))
+  curps := map[string]bool{}
+
+  for i := 0; i < 1000; i++ {
+    curp := RandomCurp(random)
+    assert.Nil(t, CheckCurp(curp), curp)
+    curps[curp] = true
+  }
+
+  assert.Len(t, curps, 1000)
+  assert.Equal(t, RandomCurp(rand.New(rand.NewSource(7))), RandomCurp(rand.New(rand.NewSource(7))))
+}
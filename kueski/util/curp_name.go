@@ -0,0 +1,190 @@
+package util
+
+import (
+  "strings"
+  "time"
+)
+
+// Applicant - Identity of a lead as declared in a form. Sex is H, M or X.
+type Applicant struct {
+  FirstName       string
+  PaternalSurname string
+  MaternalSurname string
+  BirthDate       time.Time
+  Sex             string
+}
+
+// CurpMatch - Consistency of a CURP with an Applicant.
+// Score      - Share of the compared characters that match, from 0 to 1.
+// Mismatches - Positions (1 to 18) of the CURP characters that do not match the applicant.
+type CurpMatch struct {
+  Score      float64
+  Mismatches []int
+}
+
+// curpNameParticles - Words skipped when deriving a CURP from names.
+var curpNameParticles = map[string]bool{
+  "DA": true, "DAS": true, "DE": true, "DEL": true, "DER": true, "DI": true, "DIE": true, "DD": true,
+  "EL": true, "LA": true, "LAS": true, "LE": true, "LES": true, "LOS": true, "MAC": true, "MC": true,
+  "VAN": true, "VON": true, "Y": true,
+}
+
+// curpCompoundNames - First names skipped when followed by another one, e.g. MARIA in MARIA LUISA.
+var curpCompoundNames = map[string]bool{"MARIA": true, "MA": true, "JOSE": true, "J": true}
+
+// curpInconvenientWords - RENAPO words that cannot be the first four characters of a CURP:
+// their second character is replaced with X.
+var curpInconvenientWords = map[string]bool{}
+
+func init() {
+  for _, word := range strings.Fields(`BACA BAKA BUEI BUEY CACA CACO CAGA CAGO CAKA CAKO COGE COGI COJA COJE
+    COJI COJO COLA CULO FALO FETO GETA GUEI GUEY JETA JOTO KACA KACO KAGA KAGO KAKA KAKO KOGE KOGI KOJA KOJE
+    KOJI KOJO KOLA KULO LILO LOCA LOCO LOKA LOKO MAME MAMO MEAR MEAS MEON MIAR MION MOCO MOKO MULA MULO NACA
+    NACO PEDA PEDO PENE PIPI PITO POPO PUTA PUTO QULO RATA ROBA ROBE ROBO RUIN SENO TETA VACA VAGA VAGO VAKA
+    VUEI VUEY WUEI WUEY`) {
+    curpInconvenientWords[word] = true
+  }
+}
+
+// curpAccents - Letters written without accent in a CURP. Ñ is written as X.
+var curpAccents = strings.NewReplacer(
+  "Á", "A", "É", "E", "Í", "I", "Ó", "O", "Ú", "U", "Ü", "U", "À", "A", "È", "E", "Ì", "I", "Ò", "O", "Ù", "U",
+  "Ñ", "X", "-", " ", "/", " ",
+)
+
+// MatchCurp - Checks a CURP verified by CheckCurp against the applicant, following the RENAPO derivation rules.
+// Compares the name characters (1 to 4 and 14 to 16), and the birth date (5 to 10) and sex (11) when given.
+func MatchCurp(curp string, applicant Applicant) (CurpMatch, error) {
+  if err := CheckCurp(curp); err != nil {
+    return CurpMatch{}, err
+  }
+
+  expected := map[int]byte{}
+  name := curpNameLetters(applicant)
+  consonants := curpNameConsonants(applicant)
+
+  for i := 0; i < 4; i++ {
+    expected[i+1] = name[i]
+  }
+
+  for i := 0; i < 3; i++ {
+    expected[i+14] = consonants[i]
+  }
+
+  if !applicant.BirthDate.IsZero() {
+    date := applicant.BirthDate.Format("060102")
+
+    for i := 0; i < 6; i++ {
+      expected[i+5] = date[i]
+    }
+  }
+
+  if applicant.Sex != "" {
+    expected[11] = strings.ToUpper(applicant.Sex)[0]
+  }
+
+  match := CurpMatch{Mismatches: []int{}}
+
+  for position := 1; position <= len(curp); position++ {
+    if char, ok := expected[position]; ok && curp[position-1] != char {
+      match.Mismatches = append(match.Mismatches, position)
+    }
+  }
+
+  match.Score = float64(len(expected)-len(match.Mismatches)) / float64(len(expected))
+  return match, nil
+}
+
+// curpNameLetters - First four characters of the CURP of the applicant.
+func curpNameLetters(applicant Applicant) string {
+  paternal := curpNameWord(applicant.PaternalSurname, false)
+  maternal := curpNameWord(applicant.MaternalSurname, false)
+  first := curpNameWord(applicant.FirstName, true)
+
+  letters := []byte{curpInitial(paternal), 'X', curpInitial(maternal), curpInitial(first)}
+
+  for _, char := range []byte(curpTail(paternal)) {
+    if strings.IndexByte("AEIOU", char) >= 0 {
+      letters[1] = char
+      break
+    }
+  }
+
+  if curpInconvenientWords[string(letters)] {
+    letters[1] = 'X'
+  }
+
+  return string(letters)
+}
+
+// curpNameConsonants - First internal consonants of the paternal surname, maternal surname and first name.
+func curpNameConsonants(applicant Applicant) string {
+  consonants := []byte{}
+
+  for _, word := range []string{
+    curpNameWord(applicant.PaternalSurname, false),
+    curpNameWord(applicant.MaternalSurname, false),
+    curpNameWord(applicant.FirstName, true),
+  } {
+    consonant := byte('X')
+
+    for _, char := range []byte(curpTail(word)) {
+      if char >= 'A' && char <= 'Z' && strings.IndexByte("AEIOU", char) < 0 {
+        consonant = char
+        break
+      }
+    }
+
+    consonants = append(consonants, consonant)
+  }
+
+  return string(consonants)
+}
+
+// curpNameWord - The word of a name the CURP is derived from: uppercase, without accents, particles
+// and, for first names, the compound MARIA or JOSE when another name follows. Empty if there is none.
+func curpNameWord(name string, firstName bool) string {
+  words := []string{}
+
+  for _, word := range strings.Fields(curpAccents.Replace(strings.ToUpper(name))) {
+    word = strings.Map(func(char rune) rune {
+      if char >= 'A' && char <= 'Z' {
+        return char
+      }
+
+      return -1
+    }, word)
+
+    if word != "" && !curpNameParticles[word] {
+      words = append(words, word)
+    }
+  }
+
+  if len(words) == 0 {
+    return ""
+  }
+
+  if firstName && len(words) > 1 && curpCompoundNames[words[0]] {
+    return words[1]
+  }
+
+  return words[0]
+}
+
+// curpInitial - First letter of a name word, X if the word is empty.
+func curpInitial(word string) byte {
+  if word == "" {
+    return 'X'
+  }
+
+  return word[0]
+}
+
+// curpTail - A name word without its first letter.
+func curpTail(word string) string {
+  if word == "" {
+    return ""
+  }
+
+  return word[1:]
+}
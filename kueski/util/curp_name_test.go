@@ -0,0 +1,53 @@
+package util
+
+import (
+  "testing"
+  "time"
+
+  "github.com/stretchr/testify/assert"
+)
+
+func TestMatchCurp(t *testing.T) {
+  applicant := Applicant{"Juan", "Pérez", "López", time.Date(1990, 5, 15, 0, 0, 0, 0, time.UTC), "H"}
+  match, err := MatchCurp("PELJ900515HDFRPN07", applicant)
+
+  assert.Nil(t, err)
+  assert.Equal(t, CurpMatch{1, []int{}}, match)
+
+  applicant.FirstName = "Pedro"
+  applicant.BirthDate = time.Date(1990, 5, 16, 0, 0, 0, 0, time.UTC)
+  match, err = MatchCurp("PELJ900515HDFRPN07", applicant)
+
+  assert.Nil(t, err)
+  assert.Equal(t, []int{4, 10, 16}, match.Mismatches)
+  assert.InDelta(t, 11.0/14, match.Score, 0.001)
+
+  _, err = MatchCurp("PELJ900515HDFRPN09", applicant)
+  assert.Equal(t, CurpCheckDigit, err)
+}
+
+func TestMatchCurpWithoutDateAndSex(t *testing.T) {
+  match, err := MatchCurp("PELJ900515HDFRPN07", Applicant{FirstName: "Juan", PaternalSurname: "Perez", MaternalSurname: "Lopez"})
+
+  assert.Nil(t, err)
+  assert.Equal(t, CurpMatch{1, []int{}}, match)
+}
+
+func TestCurpNameRules(t *testing.T) {
+  cases := []struct {
+    applicant  Applicant
+    letters    string
+    consonants string
+  }{
+    {Applicant{FirstName: "Juan", PaternalSurname: "Pérez", MaternalSurname: "López"}, "PELJ", "RPN"},
+    {Applicant{FirstName: "María de los Ángeles", PaternalSurname: "García", MaternalSurname: "Muñoz"}, "GAMA", "RXN"},
+    {Applicant{FirstName: "José", PaternalSurname: "de la Fuente", MaternalSurname: "Ruiz"}, "FURJ", "NZS"},
+    {Applicant{FirstName: "Ma. Luisa", PaternalSurname: "Ñúñez", MaternalSurname: ""}, "XUXL", "XXS"},
+    {Applicant{FirstName: "Ana", PaternalSurname: "Castro", MaternalSurname: "Casas"}, "CXCA", "SSN"},
+  }
+
+  for _, c := range cases {
+    assert.Equal(t, c.letters, curpNameLetters(c.applicant), c.applicant.FirstName)
+    assert.Equal(t, c.consonants, curpNameConsonants(c.applicant), c.applicant.FirstName)
+  }
+}
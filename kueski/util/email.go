@@ -0,0 +1,290 @@
+package util
+
+import (
+  "bufio"
+  "io"
+  "strings"
+  "sync"
+)
+
+// EmailIssue - Quality problem of an email address.
+type EmailIssue string
+
+// EmailSyntax     - It fails ValidateEmail.
+// EmailDisposable - The domain, or a parent domain, is a throwaway email provider.
+// EmailTypo       - The domain, not disposable, looks like a mistyped common provider, see EmailVerdict.Suggestion.
+// EmailRole       - The mailbox is a role account, e.g. admin@ or ventas@, rather than a person.
+const (
+  EmailSyntax     EmailIssue = "syntax"
+  EmailDisposable EmailIssue = "disposable"
+  EmailTypo       EmailIssue = "typo"
+  EmailRole       EmailIssue = "role"
+)
+
+// EmailVerdict - Outcome of an email assessment.
+// Suggestion - The address with the provider domain it most likely meant, for EmailTypo.
+type EmailVerdict struct {
+  Email      string
+  Issues     []EmailIssue
+  Suggestion string
+}
+
+// Has - The verdict includes the issue.
+func (verdict EmailVerdict) Has(issue EmailIssue) bool {
+  for _, found := range verdict.Issues {
+    if found == issue {
+      return true
+    }
+  }
+
+  return false
+}
+
+// defaultDisposableDomains - Throwaway email providers known when the package was released.
+var defaultDisposableDomains = []string{
+  "10minutemail.com", "burnermail.io", "discard.email", "dispostable.com", "emailondeck.com", "fakeinbox.com",
+  "getnada.com", "guerrillamail.com", "guerrillamail.net", "guerrillamail.org", "mailcatch.com", "maildrop.cc",
+  "mailinator.com", "mailnesia.com", "mintemail.com", "mohmal.com", "moakt.com", "mytemp.email", "sharklasers.com",
+  "spamgourmet.com", "temp-mail.org", "tempail.com", "tempmailo.com", "tempr.email", "throwawaymail.com",
+  "trashmail.com", "yopmail.com",
+}
+
+// defaultProviders - Common providers of the leads, the targets of typo suggestions.
+var defaultProviders = []string{
+  "gmail.com", "hotmail.com", "hotmail.es", "outlook.com", "outlook.es", "live.com", "live.com.mx", "yahoo.com",
+  "yahoo.com.mx", "icloud.com", "protonmail.com", "prodigy.net.mx",
+}
+
+// defaultKnownDomains - Legitimate providers a typo away from one of defaultProviders, never suggested a typo fix.
+var defaultKnownDomains = []string{
+  "aol.com", "email.com", "gmx.com", "gmx.es", "gmx.net", "googlemail.com", "hotmail.co.uk", "hotmail.fr",
+  "hotmail.it", "live.ca", "live.com.ar", "mac.com", "mail.com", "me.com", "msn.com", "outlook.de", "outlook.fr",
+  "pm.me", "proton.me", "protonmail.ch", "yahoo.com.ar", "yahoo.com.br", "yahoo.com.co", "yahoo.com.pe",
+  "yahoo.com.ve", "yahoo.es", "ymail.com",
+}
+
+// defaultRoles - Mailboxes of role accounts, in English and Spanish.
+var defaultRoles = []string{
+  "abuse", "admin", "administracion", "administrator", "atencion", "ayuda", "billing", "contact", "contacto",
+  "facturacion", "hello", "help", "hola", "hostmaster", "hr", "info", "marketing", "no-reply", "noreply", "office",
+  "postmaster", "rh", "root", "sales", "soporte", "support", "team", "ventas", "webmaster",
+}
+
+// typoDistance - Largest edit distance between a domain and a provider to suggest the provider:
+// one edit, or two for providers of at least longTypoDomain characters.
+const (
+  typoDistance   int = 2
+  longTypoDomain int = 12
+)
+
+// EmailAssessor - Offline email quality checks. Its lists start with the defaults of the package
+// and can be updated at any time.
+type EmailAssessor struct {
+  disposable map[string]bool
+  providers  []string
+  known      map[string]bool
+  roles      map[string]bool
+  sync.RWMutex
+}
+
+// NewEmailAssessor - EmailAssessor constructor, with the default lists.
+func NewEmailAssessor() *EmailAssessor {
+  assessor := &EmailAssessor{disposable: map[string]bool{}, known: map[string]bool{}, roles: map[string]bool{}}
+  assessor.AddDisposableDomains(defaultDisposableDomains...)
+  assessor.AddProviders(defaultProviders...)
+  assessor.AddKnownDomains(defaultKnownDomains...)
+  assessor.AddRoles(defaultRoles...)
+  return assessor
+}
+
+// AddDisposableDomains - Adds throwaway email domains, their subdomains are disposable too.
+func (assessor *EmailAssessor) AddDisposableDomains(domains ...string) {
+  assessor.Lock()
+  defer assessor.Unlock()
+
+  for _, domain := range domains {
+    assessor.disposable[strings.ToLower(strings.TrimSpace(domain))] = true
+  }
+}
+
+// LoadDisposableDomains - Adds the throwaway email domains of a list, one per line. Blank lines and
+// lines starting with # are skipped, so the public disposable domain lists can be loaded as they are.
+func (assessor *EmailAssessor) LoadDisposableDomains(reader io.Reader) error {
+  scanner := bufio.NewScanner(reader)
+  domains := []string{}
+
+  for scanner.Scan() {
+    line := strings.TrimSpace(scanner.Text())
+
+    if line != "" && !strings.HasPrefix(line, "#") {
+      domains = append(domains, line)
+    }
+  }
+
+  if err := scanner.Err(); err != nil {
+    return err
+  }
+
+  assessor.AddDisposableDomains(domains...)
+  return nil
+}
+
+// AddProviders - Adds email provider domains for typo suggestions.
+func (assessor *EmailAssessor) AddProviders(domains ...string) {
+  assessor.Lock()
+  defer assessor.Unlock()
+
+  for _, domain := range domains {
+    assessor.providers = append(assessor.providers, strings.ToLower(strings.TrimSpace(domain)))
+  }
+}
+
+// AddKnownDomains - Adds legitimate domains, never reported as typos of a provider, e.g. ymail.com for gmail.com.
+func (assessor *EmailAssessor) AddKnownDomains(domains ...string) {
+  assessor.Lock()
+  defer assessor.Unlock()
+
+  for _, domain := range domains {
+    assessor.known[strings.ToLower(strings.TrimSpace(domain))] = true
+  }
+}
+
+// AddRoles - Adds role account mailboxes.
+func (assessor *EmailAssessor) AddRoles(mailboxes ...string) {
+  assessor.Lock()
+  defer assessor.Unlock()
+
+  for _, mailbox := range mailboxes {
+    assessor.roles[strings.ToLower(strings.TrimSpace(mailbox))] = true
+  }
+}
+
+// Assess - Checks the syntax, domain and mailbox of an email address. Addresses failing the syntax
+// check get no other issue.
+func (assessor *EmailAssessor) Assess(email string) EmailVerdict {
+  verdict := EmailVerdict{Email: email, Issues: []EmailIssue{}}
+
+  if !ValidateEmail(email) {
+    verdict.Issues = append(verdict.Issues, EmailSyntax)
+    return verdict
+  }
+
+  at := strings.LastIndex(email, "@")
+  mailbox := strings.ToLower(email[:at])
+  domain := strings.ToLower(email[at+1:])
+
+  assessor.RLock()
+  defer assessor.RUnlock()
+
+  if assessor.isDisposable(domain) {
+    verdict.Issues = append(verdict.Issues, EmailDisposable)
+  } else if suggestion := assessor.suggestProvider(domain); suggestion != "" {
+    verdict.Issues = append(verdict.Issues, EmailTypo)
+    verdict.Suggestion = email[:at+1] + suggestion
+  }
+
+  // Sub-addressing, e.g. ventas+mx@, does not make a role account personal.
+  if assessor.roles[strings.SplitN(mailbox, "+", 2)[0]] {
+    verdict.Issues = append(verdict.Issues, EmailRole)
+  }
+
+  return verdict
+}
+
+// isDisposable - The domain or a parent domain is disposable. Must hold the lock.
+func (assessor *EmailAssessor) isDisposable(domain string) bool {
+  for {
+    if assessor.disposable[domain] {
+      return true
+    }
+
+    dot := strings.Index(domain, ".")
+
+    if dot < 0 {
+      return false
+    }
+
+    domain = domain[dot+1:]
+  }
+}
+
+// suggestProvider - The closest provider within the allowed edits, empty if the domain is a provider,
+// a known domain, or none is close. Must hold the lock.
+func (assessor *EmailAssessor) suggestProvider(domain string) string {
+  if assessor.known[domain] {
+    return ""
+  }
+
+  suggestion := ""
+  best := typoDistance + 1
+
+  for _, provider := range assessor.providers {
+    if provider == domain {
+      return ""
+    }
+
+    allowed := 1
+
+    if len(provider) >= longTypoDomain {
+      allowed = typoDistance
+    }
+
+    if distance := editDistance(domain, provider); distance <= allowed && distance < best {
+      suggestion, best = provider, distance
+    }
+  }
+
+  return suggestion
+}
+
+var defaultEmailAssessor = NewEmailAssessor()
+
+// AssessEmail - Assesses an email address with the default lists, see EmailAssessor.Assess.
+func AssessEmail(email string) EmailVerdict {
+  return defaultEmailAssessor.Assess(email)
+}
+
+// editDistance - Edit distance between two strings, counting insertions, deletions, substitutions
+// and transpositions of adjacent characters (optimal string alignment).
+func editDistance(a, b string) int {
+  distances := make([][]int, len(a)+1)
+
+  for i := range distances {
+    distances[i] = make([]int, len(b)+1)
+    distances[i][0] = i
+  }
+
+  for j := range distances[0] {
+    distances[0][j] = j
+  }
+
+  for i := 1; i <= len(a); i++ {
+    for j := 1; j <= len(b); j++ {
+      cost := 1
+
+      if a[i-1] == b[j-1] {
+        cost = 0
+      }
+
+      distances[i][j] = minInt(distances[i-1][j]+1, distances[i][j-1]+1, distances[i-1][j-1]+cost)
+
+      if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+        distances[i][j] = minInt(distances[i][j], distances[i-2][j-2]+1)
+      }
+    }
+  }
+
+  return distances[len(a)][len(b)]
+}
+
+func minInt(values ...int) int {
+  min := values[0]
+
+  for _, value := range values[1:] {
+    if value < min {
+      min = value
+    }
+  }
+
+  return min
+}
@@ -0,0 +1,62 @@
+package util
+
+import (
+  "strings"
+  "testing"
+
+  "github.com/stretchr/testify/assert"
+)
+
+func TestAssessEmail(t *testing.T) {
+  assert.Equal(t, EmailVerdict{"juan@gmail.com", []EmailIssue{}, ""}, AssessEmail("juan@gmail.com"))
+  assert.Equal(t, EmailVerdict{"juan@kueski.com", []EmailIssue{}, ""}, AssessEmail("juan@kueski.com"))
+  assert.Equal(t, EmailVerdict{"juan", []EmailIssue{EmailSyntax}, ""}, AssessEmail("juan"))
+
+  verdict := AssessEmail("user@gmial.com")
+  assert.Equal(t, []EmailIssue{EmailTypo}, verdict.Issues)
+  assert.Equal(t, "user@gmail.com", verdict.Suggestion)
+
+  for domain, provider := range map[string]string{
+    "gmail.con": "gmail.com", "gmal.com": "gmail.com", "hotmial.com": "hotmail.com", "hotmail.co": "hotmail.com",
+    "yaho.com.mx": "yahoo.com.mx", "yahooo.co.mx": "yahoo.com.mx", "outlok.com": "outlook.com", "prodigy.nte.mx": "prodigy.net.mx",
+  } {
+    assert.Equal(t, "user@"+provider, AssessEmail("user@"+domain).Suggestion, domain)
+  }
+
+  for _, domain := range []string{"gmx.com", "live.com.mx", "hotmail.es", "aon.com", "topmail.com", "ymail.com",
+    "mail.com", "email.com", "yahoo.com.ar", "protonmail.ch", "Mail.com"} {
+    assert.False(t, AssessEmail("user@"+domain).Has(EmailTypo), domain)
+  }
+
+  assert.True(t, AssessEmail("juan@mailinator.com").Has(EmailDisposable))
+  assert.True(t, AssessEmail("juan@inbox.Mailinator.com").Has(EmailDisposable))
+  assert.False(t, AssessEmail("juan@notmailinator.com").Has(EmailDisposable))
+
+  assert.True(t, AssessEmail("Admin@kueski.com").Has(EmailRole))
+  assert.True(t, AssessEmail("ventas+mx@kueski.com").Has(EmailRole))
+  assert.False(t, AssessEmail("administrador.juan@kueski.com").Has(EmailRole))
+  assert.Equal(t, []EmailIssue{EmailDisposable, EmailRole}, AssessEmail("info@yopmail.com").Issues)
+}
+
+func TestEmailAssessorLists(t *testing.T) {
+  assessor := NewEmailAssessor()
+
+  assert.Nil(t, assessor.LoadDisposableDomains(strings.NewReader("# disposable\n\nthrowaway.example\n")))
+  assessor.AddProviders("kueski.com")
+  assessor.AddRoles("compras")
+  assessor.AddKnownDomains("kueski.co")
+
+  assert.True(t, assessor.Assess("juan@throwaway.example").Has(EmailDisposable))
+  assert.Equal(t, "juan@kueski.com", assessor.Assess("juan@kueksi.com").Suggestion)
+  assert.False(t, assessor.Assess("juan@kueski.co").Has(EmailTypo))
+  assert.True(t, assessor.Assess("compras@kueski.com").Has(EmailRole))
+  assert.False(t, AssessEmail("juan@throwaway.example").Has(EmailDisposable))
+}
+
+func TestEditDistance(t *testing.T) {
+  assert.Equal(t, 0, editDistance("gmail.com", "gmail.com"))
+  assert.Equal(t, 1, editDistance("gmial.com", "gmail.com"))
+  assert.Equal(t, 1, editDistance("gmai.com", "gmail.com"))
+  assert.Equal(t, 3, editDistance("kitten", "sitting"))
+  assert.Equal(t, 3, editDistance("", "abc"))
+}
@@ -22,20 +22,40 @@ func BuildURL(host, path string) string {
 // headers - Headers to be included in the request.
 // body - Request body.
 func PostRequest(url string, headers map[string]string, body []byte) (*http.Response, error) {
-  req, err := http.NewRequest("POST", url, bytes.NewBuffer(body))
+  return NewPostRequest(&http.Client{})(url, headers, body)
+}
 
-  for key, value := range headers {
-    req.Header.Set(key, value)
-  }
+// NewPostRequest - Builds a PostRequestFunc on top of the given HTTP client,
+// so its transport (and any middleware chained into it) is used for every request.
+func NewPostRequest(client *http.Client) PostRequestFunc {
+  return func(url string, headers map[string]string, body []byte) (*http.Response, error) {
+    req, err := http.NewRequest("POST", url, bytes.NewBuffer(body))
 
-  client := &http.Client{}
-  resp, err := client.Do(req)
+    if err != nil {
+      return nil, errors.UnableToMakeConnection
+    }
 
-  if err != nil {
-    return nil, errors.UnableToMakeConnection
+    for key, value := range headers {
+      req.Header.Set(key, value)
+    }
+
+    resp, err := client.Do(req)
+
+    if err != nil {
+      return nil, connectionError(err)
+    }
+
+    return resp, err
+  }
+}
+
+// connectionError - ResponseError of a failed round trip.
+func connectionError(err error) error {
+  if handshakeFailure(err) {
+    return errors.TLSHandshakeFailed
   }
 
-  return resp, err
+  return errors.UnableToMakeConnection
 }
 
 // ExtractBody - Encapsulation of the task which extracts body response data.
@@ -0,0 +1,175 @@
+package util
+
+import (
+  "log"
+  "net/http"
+  "strings"
+  "time"
+)
+
+// RoundTripperFunc - Adapter to use an ordinary function as an http.RoundTripper.
+type RoundTripperFunc func(request *http.Request) (*http.Response, error)
+
+// RoundTrip - Calls the underlying function.
+func (f RoundTripperFunc) RoundTrip(request *http.Request) (*http.Response, error) {
+  return f(request)
+}
+
+// Middleware - Decorates an http.RoundTripper with extra behaviour.
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// RequestObserver - Callback invoked once a request round trip has finished.
+// statusCode is zero when the transport failed.
+type RequestObserver func(request *http.Request, statusCode int, elapsed time.Duration, err error)
+
+// SpanStarter - Callback invoked before a request is sent, returning the function that finishes the span.
+type SpanStarter func(request *http.Request) func(statusCode int, err error)
+
+// Chain - Wraps base with the given middlewares.
+// The first middleware is the outermost one, so it sees the request first and the response last.
+func Chain(base http.RoundTripper, middlewares ...Middleware) http.RoundTripper {
+  if base == nil {
+    base = http.DefaultTransport
+  }
+
+  for i := len(middlewares) - 1; i >= 0; i-- {
+    base = middlewares[i](base)
+  }
+
+  return base
+}
+
+// LoggingMiddleware - Logs method, URL, status and elapsed time of every request.
+// logf - Printf style function, log.Printf is used when nil.
+func LoggingMiddleware(logf func(format string, args ...interface{})) Middleware {
+  if logf == nil {
+    logf = log.Printf
+  }
+
+  return MetricsMiddleware(func(request *http.Request, statusCode int, elapsed time.Duration, err error) {
+    if err != nil {
+      logf("%s %s failed after %s: %v", request.Method, request.URL.Path, elapsed, err)
+      return
+    }
+
+    logf("%s %s %d %s", request.Method, request.URL.Path, statusCode, elapsed)
+  })
+}
+
+// RetryMiddleware - Retries a request of an idempotent method on connection errors and 5xx responses.
+// POST requests, e.g. lead evaluations, may have been processed before failing and are only retried
+// when opted in through methods.
+// attempts - Total number of tries, including the first one.
+// backoff - Wait before the first retry, doubled on each subsequent one.
+// methods - Non idempotent methods to retry as well, e.g. http.MethodPost.
+func RetryMiddleware(attempts int, backoff time.Duration, methods ...string) Middleware {
+  retried := map[string]bool{
+    http.MethodGet:     true,
+    http.MethodHead:    true,
+    http.MethodOptions: true,
+    http.MethodPut:     true,
+    http.MethodDelete:  true,
+  }
+
+  for _, method := range methods {
+    retried[strings.ToUpper(method)] = true
+  }
+
+  return func(next http.RoundTripper) http.RoundTripper {
+    return RoundTripperFunc(func(request *http.Request) (*http.Response, error) {
+      wait := backoff
+      response, err := next.RoundTrip(request)
+
+      if !retried[request.Method] {
+        return response, err
+      }
+
+      for try := 1; try < attempts && retryable(response, err); try++ {
+        if request.Body != nil && request.GetBody == nil {
+          break
+        }
+
+        if response != nil {
+          response.Body.Close()
+        }
+
+        select {
+        case <-request.Context().Done():
+          return nil, request.Context().Err()
+        case <-time.After(wait):
+        }
+
+        wait *= 2
+
+        retry := request.Clone(request.Context())
+
+        if request.GetBody != nil {
+          body, bodyErr := request.GetBody()
+
+          if bodyErr != nil {
+            return nil, bodyErr
+          }
+
+          retry.Body = body
+        }
+
+        response, err = next.RoundTrip(retry)
+      }
+
+      return response, err
+    })
+  }
+}
+
+// MetricsMiddleware - Reports status and latency of every request to observe.
+func MetricsMiddleware(observe RequestObserver) Middleware {
+  return func(next http.RoundTripper) http.RoundTripper {
+    return RoundTripperFunc(func(request *http.Request) (*http.Response, error) {
+      start := time.Now()
+      response, err := next.RoundTrip(request)
+      observe(request, statusOf(response), time.Since(start), err)
+      return response, err
+    })
+  }
+}
+
+// TracingMiddleware - Opens a span around every request through start.
+func TracingMiddleware(start SpanStarter) Middleware {
+  return func(next http.RoundTripper) http.RoundTripper {
+    return RoundTripperFunc(func(request *http.Request) (*http.Response, error) {
+      finish := start(request)
+      response, err := next.RoundTrip(request)
+      finish(statusOf(response), err)
+      return response, err
+    })
+  }
+}
+
+// HeaderMiddleware - Adds fixed headers to every request, without overriding those already set.
+func HeaderMiddleware(headers map[string]string) Middleware {
+  return func(next http.RoundTripper) http.RoundTripper {
+    return RoundTripperFunc(func(request *http.Request) (*http.Response, error) {
+      cloned := request.Clone(request.Context())
+
+      for key, value := range headers {
+        if cloned.Header.Get(key) == "" {
+          cloned.Header.Set(key, value)
+        }
+      }
+
+      return next.RoundTrip(cloned)
+    })
+  }
+}
+
+func retryable(response *http.Response, err error) bool {
+  return err != nil || response.StatusCode >= 500
+}
+
+func statusOf(response *http.Response) int {
+  if response == nil {
+    return 0
+  }
+
+  return response.StatusCode
+}
@@ -0,0 +1,150 @@
+package util
+
+import (
+  "fmt"
+  "io/ioutil"
+  "net/http"
+  "net/http/httptest"
+  "testing"
+  "time"
+
+  "github.com/stretchr/testify/assert"
+)
+
+func TestChainOrder(t *testing.T) {
+  calls := []string{}
+  tagger := func(name string) Middleware {
+    return func(next http.RoundTripper) http.RoundTripper {
+      return RoundTripperFunc(func(request *http.Request) (*http.Response, error) {
+        calls = append(calls, name)
+        return next.RoundTrip(request)
+      })
+    }
+  }
+  base := RoundTripperFunc(func(request *http.Request) (*http.Response, error) {
+    calls = append(calls, "base")
+    return &http.Response{StatusCode: 200}, nil
+  })
+
+  request, _ := http.NewRequest("POST", "http://kueski.com/path", nil)
+  response, err := Chain(base, tagger("first"), tagger("second")).RoundTrip(request)
+
+  assert.Nil(t, err)
+  assert.Equal(t, 200, response.StatusCode)
+  assert.Equal(t, []string{"first", "second", "base"}, calls)
+}
+
+func TestRetryMiddleware(t *testing.T) {
+  tries := 0
+  ts := httptest.NewServer(http.HandlerFunc(func(responseWriter http.ResponseWriter, request *http.Request) {
+    tries++
+    body, _ := ioutil.ReadAll(request.Body)
+    assert.Equal(t, "Body", string(body))
+
+    if tries < 3 {
+      responseWriter.WriteHeader(503)
+      return
+    }
+
+    responseWriter.WriteHeader(201)
+  }))
+  defer ts.Close()
+
+  client := &http.Client{Transport: Chain(nil, RetryMiddleware(3, time.Millisecond))}
+  response, err := NewPostRequest(client)(ts.URL, map[string]string{}, []byte("Body"))
+
+  assert.Nil(t, err)
+  assert.Equal(t, 503, response.StatusCode)
+  assert.Equal(t, 1, tries)
+
+  tries = 0
+  client = &http.Client{Transport: Chain(nil, RetryMiddleware(3, time.Millisecond, http.MethodPost))}
+  response, err = NewPostRequest(client)(ts.URL, map[string]string{}, []byte("Body"))
+
+  assert.Nil(t, err)
+  assert.Equal(t, 201, response.StatusCode)
+  assert.Equal(t, 3, tries)
+
+  tries = -10
+  client = &http.Client{Transport: Chain(nil, RetryMiddleware(2, time.Millisecond, "post"))}
+  response, err = NewPostRequest(client)(ts.URL, map[string]string{}, []byte("Body"))
+
+  assert.Nil(t, err)
+  assert.Equal(t, 503, response.StatusCode)
+  assert.Equal(t, -8, tries)
+}
+
+func TestRetryMiddlewareIdempotentMethods(t *testing.T) {
+  tries := 0
+  ts := httptest.NewServer(http.HandlerFunc(func(responseWriter http.ResponseWriter, request *http.Request) {
+    tries++
+
+    if tries < 2 {
+      responseWriter.WriteHeader(502)
+      return
+    }
+
+    responseWriter.WriteHeader(200)
+  }))
+  defer ts.Close()
+
+  client := &http.Client{Transport: Chain(nil, RetryMiddleware(3, time.Millisecond))}
+  response, err := client.Get(ts.URL)
+
+  assert.Nil(t, err)
+  assert.Equal(t, 200, response.StatusCode)
+  assert.Equal(t, 2, tries)
+}
+
+func TestMetricsAndLoggingMiddleware(t *testing.T) {
+  observed := []int{}
+  logged := []string{}
+  observer := func(request *http.Request, statusCode int, elapsed time.Duration, err error) {
+    observed = append(observed, statusCode)
+  }
+  logf := func(format string, args ...interface{}) {
+    logged = append(logged, fmt.Sprintf(format, args...))
+  }
+  base := RoundTripperFunc(func(request *http.Request) (*http.Response, error) {
+    if request.URL.Path == "/fail" {
+      return nil, fmt.Errorf("boom")
+    }
+
+    return &http.Response{StatusCode: 201}, nil
+  })
+
+  transport := Chain(base, MetricsMiddleware(observer), LoggingMiddleware(logf))
+  request, _ := http.NewRequest("POST", "http://kueski.com/ok", nil)
+  transport.RoundTrip(request)
+  request, _ = http.NewRequest("POST", "http://kueski.com/fail", nil)
+  transport.RoundTrip(request)
+
+  assert.Equal(t, []int{201, 0}, observed)
+  assert.Equal(t, 2, len(logged))
+  assert.Contains(t, logged[0], "POST /ok 201")
+  assert.Contains(t, logged[1], "POST /fail failed")
+}
+
+func TestTracingAndHeaderMiddleware(t *testing.T) {
+  finished := 0
+  start := func(request *http.Request) func(int, error) {
+    assert.Equal(t, "Value", request.Header.Get("X-Injected"))
+    assert.Equal(t, "Mine", request.Header.Get("X-Kept"))
+
+    return func(statusCode int, err error) {
+      finished = statusCode
+    }
+  }
+  base := RoundTripperFunc(func(request *http.Request) (*http.Response, error) {
+    return &http.Response{StatusCode: 202}, nil
+  })
+
+  headers := map[string]string{"X-Injected": "Value", "X-Kept": "Theirs"}
+  transport := Chain(base, HeaderMiddleware(headers), TracingMiddleware(start))
+  request, _ := http.NewRequest("POST", "http://kueski.com/path", nil)
+  request.Header.Set("X-Kept", "Mine")
+  transport.RoundTrip(request)
+
+  assert.Equal(t, 202, finished)
+  assert.Equal(t, "", request.Header.Get("X-Injected"))
+}
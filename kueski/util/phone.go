@@ -0,0 +1,108 @@
+package util
+
+import (
+  "encoding/json"
+  "regexp"
+  "strings"
+)
+
+// PhoneError - Rule of the national numbering plan a phone number breaks.
+type PhoneError string
+
+// PhoneFormat   - It is not 10 digits once the separators, +52 and the legacy prefixes are stripped.
+// PhoneAreaCode - The area code is not in the area code table.
+const (
+  PhoneFormat   PhoneError = "format"
+  PhoneAreaCode PhoneError = "area code"
+)
+
+func (err PhoneError) Error() string {
+  return "invalid phone " + string(err)
+}
+
+// PhoneInfo - A phone number of the national numbering plan.
+// National - The 10 digits, area code included.
+// E164     - The number in E.164 format, e.g. +523312345678.
+type PhoneInfo struct {
+  National string
+  AreaCode string
+  State    string
+  E164     string
+}
+
+// phoneSeparators - Characters people group phone digits with.
+var phoneSeparators = strings.NewReplacer(" ", "", "-", "", ".", "", "(", "", ")", "")
+
+var phoneDigitsRegex = regexp.MustCompile(`^\d+$`)
+
+// ParsePhone - Parses a Mexican phone number, stripping separators, the country code (+52 or 0052),
+// the mobile 1 after it and the legacy 044, 045 and 01 prefixes, then validates the area code.
+func ParsePhone(number string) (PhoneInfo, error) {
+  digits := phoneSeparators.Replace(number)
+  international := false
+
+  switch {
+  case strings.HasPrefix(digits, "+52"):
+    digits, international = digits[3:], true
+  case strings.HasPrefix(digits, "0052"):
+    digits, international = digits[4:], true
+  case len(digits) == 13 && (strings.HasPrefix(digits, "044") || strings.HasPrefix(digits, "045")):
+    digits = digits[3:]
+  case len(digits) == 12 && strings.HasPrefix(digits, "01"):
+    digits = digits[2:]
+  case len(digits) == 12 && strings.HasPrefix(digits, "52"):
+    digits, international = digits[2:], true
+  case len(digits) == 13 && strings.HasPrefix(digits, "521"):
+    digits, international = digits[2:], true
+  }
+
+  if international && len(digits) == 11 && digits[0] == '1' {
+    digits = digits[1:]
+  }
+
+  if len(digits) != 10 || !phoneDigitsRegex.MatchString(digits) {
+    return PhoneInfo{}, PhoneFormat
+  }
+
+  for _, length := range []int{2, 3} {
+    if state, ok := phoneAreaCodes[digits[:length]]; ok {
+      return PhoneInfo{digits, digits[:length], state, "+52" + digits}, nil
+    }
+  }
+
+  return PhoneInfo{}, PhoneAreaCode
+}
+
+// NormalizePhone - E.164 format of a Mexican phone number, see ParsePhone.
+func NormalizePhone(number string) (string, error) {
+  info, err := ParsePhone(number)
+  return info.E164, err
+}
+
+// ValidatePhone - Mexican phone number validation, see ParsePhone.
+func ValidatePhone(number string) bool {
+  _, err := ParsePhone(number)
+  return err == nil
+}
+
+// Phone - Phone typed field for full data structs. ValidateFullData rejects the full data with InvalidPhone
+// when it is set and invalid. An empty Phone is valid, for optional fields.
+type Phone string
+
+// MarshalJSON - Marshals a valid phone in E.164 format, any other as it is.
+func (phone Phone) MarshalJSON() ([]byte, error) {
+  if normalized, err := NormalizePhone(string(phone)); err == nil {
+    return json.Marshal(normalized)
+  }
+
+  return json.Marshal(string(phone))
+}
+
+func (phone Phone) check() error {
+  if phone == "" {
+    return nil
+  }
+
+  _, err := ParsePhone(string(phone))
+  return err
+}
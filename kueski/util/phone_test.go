@@ -0,0 +1,52 @@
+package util
+
+import (
+  "encoding/json"
+  "testing"
+
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski/errors"
+  "github.com/stretchr/testify/assert"
+)
+
+func TestParsePhone(t *testing.T) {
+  for _, number := range []string{
+    "33 1234 5678", "(33) 1234-5678", "3312345678", "+52 33 1234 5678", "+52 1 33 1234 5678",
+    "0052 33 1234 5678", "52 33 1234 5678", "521 33 1234 5678", "044 33 1234 5678", "045 33 1234 5678", "01 33 1234 5678",
+  } {
+    info, err := ParsePhone(number)
+
+    assert.Nil(t, err, number)
+    assert.Equal(t, PhoneInfo{"3312345678", "33", "Jalisco", "+523312345678"}, info, number)
+  }
+
+  info, err := ParsePhone("999.123.4567")
+
+  assert.Nil(t, err)
+  assert.Equal(t, PhoneInfo{"9991234567", "999", "Yucatán", "+529991234567"}, info)
+}
+
+func TestParsePhoneErrors(t *testing.T) {
+  for _, number := range []string{"", "1234 5678", "33 1234 567A", "+1 415 555 0100", "+52 33 1234 56789"} {
+    _, err := ParsePhone(number)
+    assert.Equal(t, PhoneFormat, err, number)
+  }
+
+  _, err := ParsePhone("100 123 4567")
+  assert.Equal(t, PhoneAreaCode, err)
+  assert.False(t, ValidatePhone("100 123 4567"))
+  assert.EqualError(t, err, "invalid phone area code")
+}
+
+func TestPhoneFullData(t *testing.T) {
+  normalized, err := NormalizePhone("55-1234-5678")
+
+  assert.Nil(t, err)
+  assert.Equal(t, "+525512345678", normalized)
+
+  blob, err := json.Marshal(map[string]Phone{"phone": "+52 1 55 1234 5678", "other": "", "invalid": "55 1234"})
+
+  assert.Nil(t, err)
+  assert.JSONEq(t, `{ "phone": "+525512345678", "other": "", "invalid": "55 1234" }`, string(blob))
+  assert.Nil(t, ValidateFullData(map[string]Phone{"phone": "55 1234 5678"}))
+  assert.Equal(t, errors.InvalidPhone, ValidateFullData(map[string]Phone{"phone": "55 1234"}))
+}
@@ -0,0 +1,229 @@
+package util
+
+import (
+  "bytes"
+  "context"
+  "fmt"
+  "io"
+  "io/ioutil"
+  "net/http"
+  "net/url"
+
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski/errors"
+)
+
+// DefaultMaxBodySize - Response body limit of NewRequester when none is given: 10 MiB.
+const DefaultMaxBodySize int64 = 10 << 20
+
+// Request - HTTP request of a Requester.
+// Context - Cancels the request, context.Background() when nil.
+// Query - Added to the query string of URL.
+// Body - Optional request body.
+type Request struct {
+  Context context.Context
+  Method  string
+  URL     string
+  Query   url.Values
+  Headers map[string]string
+  Body    io.Reader
+}
+
+// Response - HTTP response of a Requester. Body streams the response body and fails with
+// ResponseTooLarge past the size limit. It must be closed, ReadBody does it.
+type Response struct {
+  StatusCode int
+  Headers    http.Header
+  Body       io.ReadCloser
+}
+
+// Requester - Interface for web requests of any method.
+type Requester interface {
+  Do(request *Request) (*Response, error)
+}
+
+// RequesterFunc - Adapter to use an ordinary function as a Requester.
+type RequesterFunc func(request *Request) (*Response, error)
+
+// Do - Calls the underlying function.
+func (f RequesterFunc) Do(request *Request) (*Response, error) {
+  return f(request)
+}
+
+// NewRequester - Requester on top of the given HTTP client, so its transport (and any middleware chained into it)
+// is used for every request.
+// maxBodySize - Response body limit in bytes, DefaultMaxBodySize when zero or less.
+func NewRequester(client *http.Client, maxBodySize int64) Requester {
+  if maxBodySize <= 0 {
+    maxBodySize = DefaultMaxBodySize
+  }
+
+  return RequesterFunc(func(request *Request) (*Response, error) {
+    req, err := newHTTPRequest(request)
+
+    if err != nil {
+      return nil, errors.UnableToMakeConnection
+    }
+
+    resp, err := client.Do(req)
+
+    if err != nil {
+      return nil, connectionError(err)
+    }
+
+    return &Response{resp.StatusCode, resp.Header, &limitedBody{resp.Body, maxBodySize}}, nil
+  })
+}
+
+// PostRequester - Adapts a PostRequestFunc to a Requester. The function can only POST, requests of
+// any other method fail, and the response body is not size limited. The function gets no context:
+// a canceled request fails with UnableToMakeConnection without waiting for it.
+func PostRequester(post PostRequestFunc) Requester {
+  return RequesterFunc(func(request *Request) (*Response, error) {
+    if request.Method != http.MethodPost {
+      return nil, fmt.Errorf("a PostRequestFunc cannot send %s requests", request.Method)
+    }
+
+    ctx := request.Context
+
+    if ctx == nil {
+      ctx = context.Background()
+    }
+
+    if ctx.Err() != nil {
+      return nil, errors.UnableToMakeConnection
+    }
+
+    body := []byte{}
+
+    if request.Body != nil {
+      blob, err := ioutil.ReadAll(request.Body)
+
+      if err != nil {
+        return nil, errors.UnableToMakeConnection
+      }
+
+      body = blob
+    }
+
+    results := make(chan postResult, 1)
+
+    go func() {
+      resp, err := post(requestURL(request), request.Headers, body)
+      results <- postResult{resp, err}
+    }()
+
+    select {
+    case result := <-results:
+      if result.response == nil {
+        return nil, result.err
+      }
+
+      return WrapResponse(result.response), result.err
+    case <-ctx.Done():
+      go discardPostResult(results)
+      return nil, errors.UnableToMakeConnection
+    }
+  })
+}
+
+type postResult struct {
+  response *http.Response
+  err      error
+}
+
+// discardPostResult - Closes the body of a response arriving after its request was canceled.
+func discardPostResult(results chan postResult) {
+  if result := <-results; result.response != nil && result.response.Body != nil {
+    result.response.Body.Close()
+  }
+}
+
+// WrapResponse - Response wrapper of a standard library response.
+func WrapResponse(response *http.Response) *Response {
+  body := response.Body
+
+  if body == nil {
+    body = ioutil.NopCloser(bytes.NewReader(nil))
+  }
+
+  return &Response{response.StatusCode, response.Header, body}
+}
+
+// ReadBody - Reads the whole body and closes it.
+func (response *Response) ReadBody() ([]byte, error) {
+  defer response.Body.Close()
+  return ioutil.ReadAll(response.Body)
+}
+
+func newHTTPRequest(request *Request) (*http.Request, error) {
+  ctx := request.Context
+
+  if ctx == nil {
+    ctx = context.Background()
+  }
+
+  req, err := http.NewRequest(request.Method, requestURL(request), request.Body)
+
+  if err != nil {
+    return nil, err
+  }
+
+  for key, value := range request.Headers {
+    req.Header.Set(key, value)
+  }
+
+  return req.WithContext(ctx), nil
+}
+
+// requestURL - URL with the query parameters of the request added.
+func requestURL(request *Request) string {
+  if len(request.Query) == 0 {
+    return request.URL
+  }
+
+  parsed, err := url.Parse(request.URL)
+
+  if err != nil {
+    return request.URL
+  }
+
+  query := parsed.Query()
+
+  for key, values := range request.Query {
+    for _, value := range values {
+      query.Add(key, value)
+    }
+  }
+
+  parsed.RawQuery = query.Encode()
+  return parsed.String()
+}
+
+// limitedBody - Response body failing with ResponseTooLarge once more than limit bytes are read.
+type limitedBody struct {
+  body  io.ReadCloser
+  limit int64
+}
+
+func (body *limitedBody) Read(p []byte) (int, error) {
+  if body.limit < 0 {
+    return 0, errors.ResponseTooLarge
+  }
+
+  if int64(len(p)) > body.limit+1 {
+    p = p[:body.limit+1]
+  }
+
+  n, err := body.body.Read(p)
+  body.limit -= int64(n)
+
+  if body.limit < 0 {
+    return n + int(body.limit), errors.ResponseTooLarge
+  }
+
+  return n, err
+}
+
+func (body *limitedBody) Close() error {
+  return body.body.Close()
+}
@@ -0,0 +1,122 @@
+package util
+
+import (
+  "context"
+  "io/ioutil"
+  "net/http"
+  "net/http/httptest"
+  "strings"
+  "testing"
+
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski/errors"
+  "github.com/stretchr/testify/assert"
+)
+
+func TestRequesterGet(t *testing.T) {
+  ts := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+    assert.Equal(t, "GET", request.Method)
+    assert.Equal(t, "42", request.URL.Query().Get("request_id"))
+    assert.Equal(t, "yes", request.URL.Query().Get("fixed"))
+    assert.Equal(t, "Value", request.Header.Get("X-Header"))
+
+    writer.Header().Set("X-Response", "Value")
+    writer.Write([]byte("status"))
+  }))
+  defer ts.Close()
+
+  requester := NewRequester(&http.Client{}, 0)
+  response, err := requester.Do(&Request{
+    Method:  "GET",
+    URL:     ts.URL + "/path?fixed=yes",
+    Query:   map[string][]string{"request_id": {"42"}},
+    Headers: map[string]string{"X-Header": "Value"},
+  })
+
+  assert.Nil(t, err)
+  assert.Equal(t, 200, response.StatusCode)
+  assert.Equal(t, "Value", response.Headers.Get("X-Response"))
+
+  body, err := response.ReadBody()
+  assert.Nil(t, err)
+  assert.Equal(t, "status", string(body))
+}
+
+func TestRequesterBodyLimit(t *testing.T) {
+  ts := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+    body, _ := ioutil.ReadAll(request.Body)
+    writer.Write(body)
+  }))
+  defer ts.Close()
+
+  requester := NewRequester(&http.Client{}, 5)
+
+  response, err := requester.Do(&Request{Method: "POST", URL: ts.URL, Body: strings.NewReader("12345")})
+  assert.Nil(t, err)
+  body, err := response.ReadBody()
+  assert.Nil(t, err)
+  assert.Equal(t, "12345", string(body))
+
+  response, err = requester.Do(&Request{Method: "POST", URL: ts.URL, Body: strings.NewReader("123456")})
+  assert.Nil(t, err)
+  body, err = response.ReadBody()
+  assert.Equal(t, errors.ResponseTooLarge, err)
+  assert.Equal(t, "12345", string(body))
+}
+
+func TestRequesterErrors(t *testing.T) {
+  requester := NewRequester(&http.Client{}, 0)
+
+  _, err := requester.Do(&Request{Method: "GET", URL: "http://localhost:0"})
+  assert.Equal(t, errors.UnableToMakeConnection, err)
+
+  _, err = requester.Do(&Request{Method: "BAD METHOD", URL: "http://kueski.com"})
+  assert.Equal(t, errors.UnableToMakeConnection, err)
+}
+
+func TestPostRequester(t *testing.T) {
+  post := func(url string, headers map[string]string, body []byte) (*http.Response, error) {
+    assert.Equal(t, "http://kueski.com/path?page=2", url)
+    assert.Equal(t, "Body", string(body))
+    assert.Equal(t, "Value", headers["X-Header"])
+
+    return &http.Response{StatusCode: 201, Body: ioutil.NopCloser(strings.NewReader("Created"))}, nil
+  }
+
+  response, err := PostRequester(post).Do(&Request{
+    Method:  "POST",
+    URL:     "http://kueski.com/path",
+    Query:   map[string][]string{"page": {"2"}},
+    Headers: map[string]string{"X-Header": "Value"},
+    Body:    strings.NewReader("Body"),
+  })
+
+  assert.Nil(t, err)
+  assert.Equal(t, 201, response.StatusCode)
+  body, _ := response.ReadBody()
+  assert.Equal(t, "Created", string(body))
+
+  failing := func(url string, headers map[string]string, body []byte) (*http.Response, error) {
+    return nil, errors.GeneralError
+  }
+
+  response, err = PostRequester(failing).Do(&Request{Method: "POST", URL: "http://kueski.com"})
+  assert.Nil(t, response)
+  assert.Equal(t, errors.GeneralError, err)
+  assert.Equal(t, 0, WrapResponse(&http.Response{}).StatusCode)
+
+  _, err = PostRequester(post).Do(&Request{Method: "GET", URL: "http://kueski.com"})
+  assert.EqualError(t, err, "a PostRequestFunc cannot send GET requests")
+
+  ctx, cancel := context.WithCancel(context.Background())
+  release := make(chan struct{})
+  blocking := func(url string, headers map[string]string, body []byte) (*http.Response, error) {
+    <-release
+    return &http.Response{StatusCode: 201}, nil
+  }
+  go cancel()
+
+  response, err = PostRequester(blocking).Do(&Request{Context: ctx, Method: "POST", URL: "http://kueski.com"})
+  close(release)
+  assert.Nil(t, response)
+  assert.Equal(t, errors.UnableToMakeConnection, err)
+}
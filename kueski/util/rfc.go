@@ -0,0 +1,116 @@
+package util
+
+import (
+  "regexp"
+  "strconv"
+  "time"
+  "unicode/utf8"
+)
+
+// RfcError - Rule of the RFC format an RFC breaks.
+type RfcError string
+
+// RfcLength     - It is not 13 (persona física) or 12 (persona moral) characters long.
+// RfcFormat     - Letters and digits are not where they belong.
+// RfcDate       - The birth or incorporation date is not a calendar date.
+// RfcCheckDigit - The homoclave verification digit does not match.
+const (
+  RfcLength     RfcError = "length"
+  RfcFormat     RfcError = "format"
+  RfcDate       RfcError = "date"
+  RfcCheckDigit RfcError = "check digit"
+)
+
+func (err RfcError) Error() string {
+  return "invalid RFC " + string(err)
+}
+
+// rfcAlphabet - Value of each character in the check digit computation.
+const rfcAlphabet string = "0123456789ABCDEFGHIJKLMN&OPQRSTUVWXYZ Ñ"
+
+var rfcRegex = regexp.MustCompile(`^[A-ZÑ&]{3,4}\d{6}[A-Z0-9]{2}[0-9A]$`)
+
+// CheckRfc - RFC verification for persona física (13 characters) and persona moral (12 characters):
+// format, date and homoclave check digit. Returns the RfcError of the first rule broken, nil if valid.
+func CheckRfc(rfc string) error {
+  length := utf8.RuneCountInString(rfc)
+
+  if length != 12 && length != 13 {
+    return RfcLength
+  }
+
+  if !rfcRegex.MatchString(rfc) {
+    return RfcFormat
+  }
+
+  runes := []rune(rfc)
+  date := string(runes[length-9 : length-3])
+  year, _ := strconv.Atoi(date[0:2])
+  month, _ := strconv.Atoi(date[2:4])
+  day, _ := strconv.Atoi(date[4:6])
+
+  // The century is unknown, 2000 + YY is a leap year exactly when 1900 + YY or 2000 + YY is.
+  parsed := time.Date(2000+year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+
+  if int(parsed.Month()) != month || parsed.Day() != day {
+    return RfcDate
+  }
+
+  if rfcCheckDigit(string(runes[:length-1])) != runes[length-1] {
+    return RfcCheckDigit
+  }
+
+  return nil
+}
+
+// ValidateRfc - RFC validation, see CheckRfc for the rule broken.
+func ValidateRfc(rfc string) bool {
+  return CheckRfc(rfc) == nil
+}
+
+// RfcFromCurp - First ten RFC characters (name letters and birth date) of the holder of a valid CURP.
+// The homoclave is assigned by the SAT and cannot be derived. When RENAPO replaced the second letter of
+// an inconvenient word with X, the RFC derived may differ from the one assigned by the SAT.
+func RfcFromCurp(curp string) (string, error) {
+  if err := CheckCurp(curp); err != nil {
+    return "", err
+  }
+
+  return curp[:10], nil
+}
+
+// Rfc - RFC typed field for full data structs. ValidateFullData rejects the full data with InvalidRfc
+// when it is set and invalid. An empty Rfc is valid, for optional fields.
+type Rfc string
+
+func (rfc Rfc) check() error {
+  if rfc == "" {
+    return nil
+  }
+
+  return CheckRfc(string(rfc))
+}
+
+// rfcCheckDigit - Homoclave verification digit of the first 12 (persona física) or 11 (persona moral)
+// characters of an RFC. Those of a persona moral are padded with a leading space.
+func rfcCheckDigit(rfc string) rune {
+  runes := []rune(rfc)
+
+  if len(runes) == 11 {
+    runes = append([]rune{' '}, runes...)
+  }
+
+  alphabet := []rune(rfcAlphabet)
+  sum := 0
+
+  for i, char := range runes {
+    for value, letter := range alphabet {
+      if letter == char {
+        sum += value * (13 - i)
+        break
+      }
+    }
+  }
+
+  return alphabet[(11-sum%11)%11]
+}
@@ -0,0 +1,207 @@
+package util
+
+import (
+  "crypto/sha256"
+  "crypto/tls"
+  "crypto/x509"
+  "encoding/base64"
+  "fmt"
+  "io/ioutil"
+  "net"
+  "net/http"
+  "net/url"
+  "strings"
+  "time"
+)
+
+// TransportSettings - Network configuration of the HTTP transport.
+// ProxyURL - HTTP(S) proxy for every request. When empty, HTTP_PROXY, HTTPS_PROXY and NO_PROXY are read from the environment.
+// NoProxy - Comma separated hosts, domains (".kueski.com"), IPs or CIDRs reached without the proxy. "*" disables it.
+// RootCAFile - PEM file with the root CAs trusted instead of the system pool.
+// PinnedSPKI - Base64 SHA-256 hashes of the accepted public keys (SPKI). Some certificate of the chain must match one.
+// CertFile, KeyFile - PEM client certificate and key presented for mutual TLS.
+// HandshakeTimeout - TLS handshake timeout, 10 seconds when zero.
+type TransportSettings struct {
+  ProxyURL         string
+  NoProxy          string
+  RootCAFile       string
+  PinnedSPKI       []string
+  CertFile         string
+  KeyFile          string
+  HandshakeTimeout time.Duration
+}
+
+// PinMismatchError - No certificate presented by the server matches the pinned public keys.
+type PinMismatchError struct {
+  Host string
+}
+
+func (err PinMismatchError) Error() string {
+  return fmt.Sprintf("tls: no certificate of %s matches the pinned public keys", err.Host)
+}
+
+// NewTransport - Builds an HTTP transport with the proxy and TLS settings.
+// Fails when the proxy URL, CA file or client certificate can not be loaded.
+func NewTransport(settings TransportSettings) (*http.Transport, error) {
+  proxy, err := proxyFunc(settings.ProxyURL, settings.NoProxy)
+
+  if err != nil {
+    return nil, err
+  }
+
+  config, err := tlsConfig(settings)
+
+  if err != nil {
+    return nil, err
+  }
+
+  if settings.HandshakeTimeout <= 0 {
+    settings.HandshakeTimeout = 10 * time.Second
+  }
+
+  return &http.Transport{
+    Proxy:               proxy,
+    DialContext:         (&net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}).DialContext,
+    TLSClientConfig:     config,
+    TLSHandshakeTimeout: settings.HandshakeTimeout,
+    IdleConnTimeout:     90 * time.Second,
+    MaxIdleConns:        100,
+  }, nil
+}
+
+// SPKIHash - Base64 SHA-256 hash of the certificate public key, as expected by PinnedSPKI.
+func SPKIHash(certificate *x509.Certificate) string {
+  sum := sha256.Sum256(certificate.RawSubjectPublicKeyInfo)
+  return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+func proxyFunc(proxyURL, noProxy string) (func(*http.Request) (*url.URL, error), error) {
+  if proxyURL == "" {
+    return http.ProxyFromEnvironment, nil
+  }
+
+  proxy, err := url.Parse(proxyURL)
+
+  if err != nil || proxy.Host == "" {
+    return nil, fmt.Errorf("invalid proxy URL %q", proxyURL)
+  }
+
+  return func(request *http.Request) (*url.URL, error) {
+    if bypassProxy(request.URL.Host, noProxy) {
+      return nil, nil
+    }
+
+    return proxy, nil
+  }, nil
+}
+
+// bypassProxy - Whether host (with optional port) matches some NO_PROXY entry.
+func bypassProxy(host, noProxy string) bool {
+  hostname, port := host, ""
+
+  if h, p, err := net.SplitHostPort(host); err == nil {
+    hostname, port = h, p
+  }
+
+  hostname = strings.ToLower(hostname)
+  ip := net.ParseIP(hostname)
+
+  for _, entry := range strings.Split(noProxy, ",") {
+    entry = strings.ToLower(strings.TrimSpace(entry))
+
+    if entry == "" {
+      continue
+    }
+
+    if entry == "*" {
+      return true
+    }
+
+    if _, network, err := net.ParseCIDR(entry); err == nil {
+      if ip != nil && network.Contains(ip) {
+        return true
+      }
+
+      continue
+    }
+
+    if h, p, err := net.SplitHostPort(entry); err == nil {
+      if p != port {
+        continue
+      }
+
+      entry = h
+    }
+
+    if hostname == strings.TrimPrefix(entry, ".") || strings.HasSuffix(hostname, "."+strings.TrimPrefix(entry, ".")) {
+      return true
+    }
+  }
+
+  return false
+}
+
+func tlsConfig(settings TransportSettings) (*tls.Config, error) {
+  config := &tls.Config{MinVersion: tls.VersionTLS12}
+
+  if settings.RootCAFile != "" {
+    blob, err := ioutil.ReadFile(settings.RootCAFile)
+
+    if err != nil {
+      return nil, fmt.Errorf("unable to read root CA file: %v", err)
+    }
+
+    pool := x509.NewCertPool()
+
+    if !pool.AppendCertsFromPEM(blob) {
+      return nil, fmt.Errorf("no PEM certificates found in %s", settings.RootCAFile)
+    }
+
+    config.RootCAs = pool
+  }
+
+  if settings.CertFile != "" || settings.KeyFile != "" {
+    certificate, err := tls.LoadX509KeyPair(settings.CertFile, settings.KeyFile)
+
+    if err != nil {
+      return nil, fmt.Errorf("unable to load client certificate: %v", err)
+    }
+
+    config.Certificates = []tls.Certificate{certificate}
+  }
+
+  if len(settings.PinnedSPKI) > 0 {
+    pins := map[string]bool{}
+
+    for _, pin := range settings.PinnedSPKI {
+      pins[pin] = true
+    }
+
+    config.VerifyConnection = func(state tls.ConnectionState) error {
+      for _, certificate := range state.PeerCertificates {
+        if pins[SPKIHash(certificate)] {
+          return nil
+        }
+      }
+
+      return PinMismatchError{state.ServerName}
+    }
+  }
+
+  return config, nil
+}
+
+// handshakeFailure - Whether err comes from a failed TLS handshake or certificate verification.
+func handshakeFailure(err error) bool {
+  if urlErr, ok := err.(*url.Error); ok {
+    err = urlErr.Err
+  }
+
+  switch err.(type) {
+  case PinMismatchError, *tls.CertificateVerificationError, tls.RecordHeaderError,
+    x509.UnknownAuthorityError, x509.HostnameError, x509.CertificateInvalidError:
+    return true
+  }
+
+  return err != nil && strings.HasPrefix(err.Error(), "remote error: tls:")
+}
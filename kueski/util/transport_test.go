@@ -0,0 +1,132 @@
+package util
+
+import (
+  "crypto/ecdsa"
+  "crypto/elliptic"
+  "crypto/rand"
+  "crypto/tls"
+  "crypto/x509"
+  "crypto/x509/pkix"
+  "encoding/pem"
+  "io/ioutil"
+  "math/big"
+  "net/http"
+  "net/http/httptest"
+  "net/url"
+  "os"
+  "path/filepath"
+  "testing"
+  "time"
+
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski/errors"
+  "github.com/stretchr/testify/assert"
+)
+
+func writePEM(t *testing.T, dir, name, kind string, der []byte) string {
+  path := filepath.Join(dir, name)
+  err := ioutil.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: kind, Bytes: der}), 0600)
+  assert.Nil(t, err)
+  return path
+}
+
+func tlsPost(t *testing.T, settings TransportSettings, url string) error {
+  transport, err := NewTransport(settings)
+  assert.Nil(t, err)
+
+  response, err := NewPostRequest(&http.Client{Transport: transport})(url, nil, []byte("{}"))
+
+  if err == nil {
+    response.Body.Close()
+  }
+
+  return err
+}
+
+func TestTransportRootCAAndPinning(t *testing.T) {
+  ts := httptest.NewTLSServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {}))
+  defer ts.Close()
+
+  dir, _ := ioutil.TempDir("", "transport")
+  defer os.RemoveAll(dir)
+  caFile := writePEM(t, dir, "ca.pem", "CERTIFICATE", ts.Certificate().Raw)
+
+  assert.Equal(t, errors.TLSHandshakeFailed, tlsPost(t, TransportSettings{}, ts.URL))
+  assert.Nil(t, tlsPost(t, TransportSettings{RootCAFile: caFile}, ts.URL))
+  assert.Nil(t, tlsPost(t, TransportSettings{RootCAFile: caFile, PinnedSPKI: []string{SPKIHash(ts.Certificate())}}, ts.URL))
+  assert.Equal(t, errors.TLSHandshakeFailed, tlsPost(t, TransportSettings{RootCAFile: caFile, PinnedSPKI: []string{"AAAA"}}, ts.URL))
+}
+
+func TestTransportClientCertificate(t *testing.T) {
+  ts := httptest.NewUnstartedServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+    assert.Equal(t, "affiliate", request.TLS.PeerCertificates[0].Subject.CommonName)
+  }))
+  ts.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+  ts.StartTLS()
+  defer ts.Close()
+
+  dir, _ := ioutil.TempDir("", "transport")
+  defer os.RemoveAll(dir)
+  caFile := writePEM(t, dir, "ca.pem", "CERTIFICATE", ts.Certificate().Raw)
+
+  key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+  template := &x509.Certificate{
+    SerialNumber: big.NewInt(1),
+    Subject:      pkix.Name{CommonName: "affiliate"},
+    NotBefore:    time.Now().Add(-time.Hour),
+    NotAfter:     time.Now().Add(time.Hour),
+    ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+  }
+  der, _ := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+  keyDer, _ := x509.MarshalECPrivateKey(key)
+  certFile := writePEM(t, dir, "client.pem", "CERTIFICATE", der)
+  keyFile := writePEM(t, dir, "client-key.pem", "EC PRIVATE KEY", keyDer)
+
+  assert.Equal(t, errors.TLSHandshakeFailed, tlsPost(t, TransportSettings{RootCAFile: caFile}, ts.URL))
+  assert.Nil(t, tlsPost(t, TransportSettings{RootCAFile: caFile, CertFile: certFile, KeyFile: keyFile}, ts.URL))
+}
+
+func TestNewTransportErrors(t *testing.T) {
+  _, err := NewTransport(TransportSettings{ProxyURL: "not a url"})
+  assert.NotNil(t, err)
+
+  _, err = NewTransport(TransportSettings{RootCAFile: "/does/not/exist.pem"})
+  assert.NotNil(t, err)
+
+  _, err = NewTransport(TransportSettings{CertFile: "/does/not/exist.pem", KeyFile: "/does/not/exist.pem"})
+  assert.NotNil(t, err)
+
+  transport, err := NewTransport(TransportSettings{})
+  assert.Nil(t, err)
+  assert.Equal(t, 10*time.Second, transport.TLSHandshakeTimeout)
+}
+
+func TestTransportProxy(t *testing.T) {
+  transport, err := NewTransport(TransportSettings{ProxyURL: "http://proxy.corp:3128", NoProxy: "localhost,.internal.corp,10.0.0.0/8,kueski.com:8443"})
+  assert.Nil(t, err)
+
+  proxies := map[string]string{
+    "https://api.kueski.com/path":    "http://proxy.corp:3128",
+    "https://kueski.com:8443/path":   "",
+    "https://kueski.com/path":        "http://proxy.corp:3128",
+    "http://localhost:8080/path":     "",
+    "https://api.internal.corp/path": "",
+    "https://internal.corp/path":     "",
+    "https://10.1.2.3/path":          "",
+    "https://192.168.1.1/path":       "http://proxy.corp:3128",
+    "https://notinternal.corp/path":  "http://proxy.corp:3128",
+  }
+
+  for rawURL, expected := range proxies {
+    target, _ := url.Parse(rawURL)
+    proxy, err := transport.Proxy(&http.Request{URL: target})
+    assert.Nil(t, err)
+
+    if expected == "" {
+      assert.Nil(t, proxy, rawURL)
+    } else {
+      assert.Equal(t, expected, proxy.String(), rawURL)
+    }
+  }
+
+  assert.True(t, bypassProxy("anything.com", "*"))
+}
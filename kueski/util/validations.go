@@ -2,6 +2,7 @@ package util
 
 import (
   "encoding/json"
+  "reflect"
   "regexp"
 
   "github.com/KueskiEngineering/go_affiliate-marketing/kueski/errors"
@@ -17,26 +18,109 @@ func ValidateEmail(email string) bool {
   return emailRegex.MatchString(email)
 }
 
-// ValidateCurp - CURP validation.
+// ValidateCurp - CURP validation, see CheckCurp for the rule broken.
 func ValidateCurp(curp string) bool {
-  curpRegex := regexp.MustCompile("^[A-Z]{4}\\d{2}(0[1-9]|10|11|12)" +
-    "(0[1-9]|[1-2][0-9]|30|31)(H|M)(AS|BC|BS|CC|CS|CH|CL|CM|DF|DG" +
-    "|MC|GT|GR|HG|JC|MN|MS|NT|NL|OC|PL|QT|QR|SP|SL|SR|TC|TS|TL|VZ" +
-    "|YN|ZS|NE)[B-DF-HJ-NP-TV-Z]{3}[\\dA-Z]\\d$")
-  return curpRegex.MatchString(curp)
+  return CheckCurp(curp) == nil
 }
 
-// ValidateFullData - Validation of extra lead data.
+// FullDataValidator - Full data able to validate its own fields, e.g. kueski.LeadProfile.
+type FullDataValidator interface {
+  Validate() error
+}
+
+// FullDataFieldsError - InvalidFullDataFields along with the error returned by Validate, e.g. a kueski.LeadProfileError.
+type FullDataFieldsError struct {
+  Err error
+}
+
+func (err FullDataFieldsError) Error() string {
+  return errors.InvalidFullDataFields.String() + ": " + err.Err.Error()
+}
+
+// Unwrap - The error returned by Validate.
+func (err FullDataFieldsError) Unwrap() error {
+  return err.Err
+}
+
+// Is - Matches InvalidFullDataFields.
+func (err FullDataFieldsError) Is(target error) bool {
+  return target == errors.InvalidFullDataFields
+}
+
+// ValidateFullData - Validation of extra lead data, including its typed fields: Rfc, Clabe, CardNumber and Phone.
+// Full data implementing FullDataValidator fails with a FullDataFieldsError when Validate fails.
 func ValidateFullData(fullData interface{}) error {
-  if fullData == nil {
+  if value := reflect.ValueOf(fullData); fullData == nil || (value.Kind() == reflect.Ptr && value.IsNil()) {
     return errors.MissingFullData
   }
 
-  _, err := json.Marshal(fullData)
+  if validator, ok := fullData.(FullDataValidator); ok {
+    if err := validator.Validate(); err != nil {
+      return FullDataFieldsError{err}
+    }
+  }
 
-  if err != nil {
+  if _, err := json.Marshal(fullData); err != nil {
     return errors.InvalidFullDataFormat
   }
 
+  switch checkTypedFields(reflect.ValueOf(fullData)).(type) {
+  case RfcError:
+    return errors.InvalidRfc
+  case ClabeError:
+    return errors.InvalidClabe
+  case CardError:
+    return errors.InvalidCardNumber
+  case PhoneError:
+    return errors.InvalidPhone
+  }
+
+  return nil
+}
+
+// typedField - Full data field type checking its own value, e.g. Rfc.
+type typedField interface {
+  check() error
+}
+
+// checkTypedFields - Error of the first invalid typed field of value, walking the exported struct fields,
+// pointers, interfaces, slices, arrays and maps json.Marshal would marshal. Must be called on marshalable
+// values, so there are no cycles.
+func checkTypedFields(value reflect.Value) error {
+  if !value.IsValid() || ((value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface) && value.IsNil()) {
+    return nil
+  }
+
+  if value.CanInterface() {
+    if field, ok := value.Interface().(typedField); ok {
+      return field.check()
+    }
+  }
+
+  switch value.Kind() {
+  case reflect.Ptr, reflect.Interface:
+    return checkTypedFields(value.Elem())
+  case reflect.Struct:
+    for i := 0; i < value.NumField(); i++ {
+      if field := value.Type().Field(i); field.PkgPath == "" && field.Tag.Get("json") != "-" {
+        if err := checkTypedFields(value.Field(i)); err != nil {
+          return err
+        }
+      }
+    }
+  case reflect.Slice, reflect.Array:
+    for i := 0; i < value.Len(); i++ {
+      if err := checkTypedFields(value.Index(i)); err != nil {
+        return err
+      }
+    }
+  case reflect.Map:
+    for _, key := range value.MapKeys() {
+      if err := checkTypedFields(value.MapIndex(key)); err != nil {
+        return err
+      }
+    }
+  }
+
   return nil
 }
@@ -1,7 +1,9 @@
 package util
 
 import (
+  "encoding/json"
   "testing"
+  "time"
 
   "github.com/KueskiEngineering/go_affiliate-marketing/kueski/errors"
   "github.com/stretchr/testify/assert"
@@ -15,7 +17,7 @@ func TestEmailValidation(t *testing.T) {
 }
 
 func TestCurpValidation(t *testing.T) {
-  assert.True(t, ValidateCurp("ABCD920113MSLXYZ01"))
+  assert.True(t, ValidateCurp("ABCD920113MSLXYZ03"))
   assert.False(t, ValidateCurp("ABCD920113MSLXY101"))
   assert.False(t, ValidateCurp("AB2011CD93MSLXY101"))
   assert.False(t, ValidateCurp("AB201SLXY101"))
@@ -45,3 +47,93 @@ func TestFullDataValidation(t *testing.T) {
   assert.Equal(t, errors.MissingFullData, nilErr)
   assert.Equal(t, errors.InvalidFullDataFormat, err)
 }
+
+func TestCheckCurp(t *testing.T) {
+  assert.Nil(t, CheckCurp("BADD110313HCMLNS06"))
+  assert.Nil(t, CheckCurp("BADD050313HCMLNSA8"))
+  assert.Equal(t, CurpLength, CheckCurp("BADD110313HCMLNS0"))
+  assert.Equal(t, CurpFormat, CheckCurp("BADD11031HHCMLNS06"))
+  assert.Equal(t, CurpBirthDate, CheckCurp("ABCD920231MSLXYZ03"))
+  assert.Equal(t, CurpBirthDate, CheckCurp("BADD010229HCMLNSA1"))
+  assert.Equal(t, CurpSex, CheckCurp("BADD110313QCMLNS06"))
+  assert.Equal(t, CurpState, CheckCurp("BADD110313HXXLNS06"))
+  assert.Equal(t, CurpConsonants, CheckCurp("BADD110313HCMANS06"))
+  assert.Equal(t, CurpCheckDigit, CheckCurp("BADD110313HCMLNS09"))
+  assert.EqualError(t, CheckCurp("BADD110313HCMLNS09"), "invalid CURP check digit")
+}
+
+func TestParseCurp(t *testing.T) {
+  info, err := ParseCurp("BADD110313HCMLNS06", time.Date(2011, 3, 12, 0, 0, 0, 0, time.UTC))
+
+  assert.Nil(t, err)
+  assert.Equal(t, CurpInfo{time.Date(1911, 3, 13, 0, 0, 0, 0, time.UTC), 99, "H", "CM", "Colima", false}, info)
+
+  info, err = ParseCurp("BADD050313HCMLNSA8", time.Date(2023, 3, 13, 0, 0, 0, 0, time.UTC))
+
+  assert.Nil(t, err)
+  assert.Equal(t, 2005, info.BirthDate.Year())
+  assert.Equal(t, 18, info.Age)
+
+  info, err = ParseCurp("BADD110313HNELNS07", time.Now())
+
+  assert.Nil(t, err)
+  assert.True(t, info.BornAbroad)
+  assert.Equal(t, "Nacido en el extranjero", info.StateName)
+
+  _, err = ParseCurp("BADD110313HCMLNS09", time.Now())
+  assert.Equal(t, CurpCheckDigit, err)
+}
+
+func TestAge(t *testing.T) {
+  birthDate := time.Date(2000, 2, 29, 0, 0, 0, 0, time.UTC)
+
+  assert.Equal(t, 17, Age(birthDate, time.Date(2018, 2, 28, 0, 0, 0, 0, time.UTC)))
+  assert.Equal(t, 18, Age(birthDate, time.Date(2018, 3, 1, 0, 0, 0, 0, time.UTC)))
+  assert.Equal(t, 20, Age(birthDate, time.Date(2020, 2, 29, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestCheckRfc(t *testing.T) {
+  assert.Nil(t, CheckRfc("GODE561231GR8"))
+  assert.Nil(t, CheckRfc("GODE000229GR4"))
+  assert.Nil(t, CheckRfc("MAG041126GT8"))
+  assert.Nil(t, CheckRfc("ÑAÑ010101ABA"))
+  assert.True(t, ValidateRfc("ABC680524P73"))
+  assert.False(t, ValidateRfc("ABC680524P76"))
+  assert.Equal(t, RfcLength, CheckRfc("GODE561231"))
+  assert.Equal(t, RfcFormat, CheckRfc("G0DE561231GR8"))
+  assert.Equal(t, RfcDate, CheckRfc("GODE560231GR8"))
+  assert.Equal(t, RfcCheckDigit, CheckRfc("GODE561231GR9"))
+  assert.EqualError(t, CheckRfc("GODE561231GR9"), "invalid RFC check digit")
+}
+
+func TestRfcFromCurp(t *testing.T) {
+  rfc, err := RfcFromCurp("PELJ900515HDFRPN07")
+
+  assert.Nil(t, err)
+  assert.Equal(t, "PELJ900515", rfc)
+
+  _, err = RfcFromCurp("PELJ900515HDFRPN09")
+  assert.Equal(t, CurpCheckDigit, err)
+}
+
+type RfcFullData struct {
+  Name string
+  Rfc  Rfc
+}
+
+func TestRfcFullDataValidation(t *testing.T) {
+  assert.Nil(t, ValidateFullData(RfcFullData{"Juan", "GODE561231GR8"}))
+  assert.Nil(t, ValidateFullData(RfcFullData{"Juan", ""}))
+  assert.Equal(t, errors.InvalidRfc, ValidateFullData(RfcFullData{"Juan", "GODE561231GR9"}))
+  assert.Equal(t, errors.InvalidRfc, ValidateFullData(map[string]interface{}{"partner": RfcFullData{"Juan", "GODE"}}))
+  assert.Equal(t, errors.InvalidRfc, ValidateFullData(&struct{ Partners []*RfcFullData }{[]*RfcFullData{nil, {"Juan", "GODE"}}}))
+  assert.Nil(t, ValidateFullData(struct {
+    Rfc   *Rfc
+    other Rfc
+    Skip  Rfc `json:"-"`
+  }{nil, "GODE", "GODE"}))
+
+  blob, err := json.Marshal(RfcFullData{"Juan", "GODE561231GR9"})
+  assert.Nil(t, err)
+  assert.Equal(t, `{"Name":"Juan","Rfc":"GODE561231GR9"}`, string(blob))
+}
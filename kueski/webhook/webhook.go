@@ -0,0 +1,231 @@
+// Package webhook receives the lead lifecycle callbacks of Kueski: evaluated, approved, funded and rejected.
+// Deliveries are signed like the API requests, with an APIAuth HMAC over the canonical string of util.Canonical.
+package webhook
+
+import (
+  "crypto/hmac"
+  "encoding/json"
+  "io/ioutil"
+  "net/http"
+  "strings"
+  "sync"
+  "time"
+
+  "github.com/KueskiEngineering/go_affiliate-marketing/kueski/util"
+)
+
+// NonceHeader - Header carrying the unique identifier of a delivery.
+const NonceHeader string = "X-Kueski-Nonce"
+
+// maxBodySize - Largest accepted delivery: 1 MiB.
+const maxBodySize int64 = 1 << 20
+
+// EventType - Lead lifecycle event.
+type EventType string
+
+// EventEvaluated - The lead was evaluated.
+// EventApproved  - The loan was approved.
+// EventFunded    - The loan was disbursed.
+// EventRejected  - The loan was rejected.
+const (
+  EventEvaluated EventType = "lead.evaluated"
+  EventApproved  EventType = "lead.approved"
+  EventFunded    EventType = "lead.funded"
+  EventRejected  EventType = "lead.rejected"
+)
+
+var eventTypes = map[EventType]bool{EventEvaluated: true, EventApproved: true, EventFunded: true, EventRejected: true}
+
+// Event - A lead lifecycle callback.
+// Reason - Why the lead was rejected, if it was.
+type Event struct {
+  ID         string    `json:"id"`
+  Type       EventType `json:"type"`
+  RequestID  string    `json:"request_id"`
+  Reason     string    `json:"reason,omitempty"`
+  OccurredAt time.Time `json:"occurred_at"`
+}
+
+// NonceStore - Remembers the nonces and signatures of accepted deliveries to reject replays.
+type NonceStore interface {
+  // Add - Records the nonce until expiration, returning false if it was already recorded.
+  Add(nonce string, expiration time.Time) bool
+  // Remove - Forgets the nonce, so a delivery that was not processed can be retried.
+  Remove(nonce string)
+}
+
+// Options - Handler configuration.
+// Tolerance - Maximum difference between the Date of a delivery and the current time, 5 minutes when zero.
+// Nonces - Replay protection store, a MemoryNonceStore when nil.
+type Options struct {
+  Tolerance time.Duration
+  Nonces    NonceStore
+}
+
+// Handler - http.Handler verifying the deliveries and passing the events to the callback.
+// Responses: 200 once the callback succeeds, 500 if it fails so the delivery is retried, and accepted again,
+// 401 on a bad signature or a stale Date, 409 on replays, 400 on malformed events.
+type Handler struct {
+  apiKey    string
+  secretKey string
+  callback  func(Event) error
+  tolerance time.Duration
+  nonces    NonceStore
+  now       func() time.Time
+}
+
+// NewHandler - Handler constructor.
+// apiKey, secretKey - Credentials Kueski signs the deliveries with.
+// callback - Receives every verified event.
+func NewHandler(apiKey, secretKey string, callback func(Event) error, options Options) *Handler {
+  if options.Tolerance <= 0 {
+    options.Tolerance = 5 * time.Minute
+  }
+
+  if options.Nonces == nil {
+    options.Nonces = NewMemoryNonceStore()
+  }
+
+  return &Handler{apiKey, secretKey, callback, options.Tolerance, options.Nonces, time.Now}
+}
+
+// ServeHTTP - Verifies and dispatches a delivery.
+func (handler *Handler) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+  if request.Method != http.MethodPost {
+    http.Error(writer, "method not allowed", http.StatusMethodNotAllowed)
+    return
+  }
+
+  body, err := ioutil.ReadAll(http.MaxBytesReader(writer, request.Body, maxBodySize))
+
+  if err != nil {
+    http.Error(writer, "unreadable body", http.StatusBadRequest)
+    return
+  }
+
+  date, err := http.ParseTime(request.Header.Get("Date"))
+
+  if err != nil || !handler.fresh(date) {
+    http.Error(writer, "stale or missing Date", http.StatusUnauthorized)
+    return
+  }
+
+  if !handler.verify(request, string(body), date) {
+    http.Error(writer, "invalid signature", http.StatusUnauthorized)
+    return
+  }
+
+  nonce := request.Header.Get(NonceHeader)
+
+  if nonce == "" {
+    http.Error(writer, "missing nonce", http.StatusUnauthorized)
+    return
+  }
+
+  var event Event
+
+  if err := json.Unmarshal(body, &event); err != nil || !eventTypes[event.Type] || event.RequestID == "" {
+    http.Error(writer, "malformed event", http.StatusBadRequest)
+    return
+  }
+
+  // The nonce is not part of the signature, so the signature itself is remembered too:
+  // the same signed delivery sent again with another nonce is still a replay.
+  // Both are recorded before the callback, so concurrent replays are rejected, and forgotten
+  // if it fails, so Kueski can retry the delivery.
+  signature := request.Header.Get("Authorization")
+  expiration := date.Add(handler.tolerance)
+  newNonce := handler.nonces.Add(nonce, expiration)
+  newSignature := handler.nonces.Add(signature, expiration)
+
+  if !newNonce || !newSignature {
+    handler.release(nonce, newNonce, signature, newSignature)
+    http.Error(writer, "replayed delivery", http.StatusConflict)
+    return
+  }
+
+  if err := handler.callback(event); err != nil {
+    handler.release(nonce, true, signature, true)
+    http.Error(writer, "event not processed", http.StatusInternalServerError)
+    return
+  }
+
+  writer.WriteHeader(http.StatusOK)
+}
+
+// release - Forgets the nonce and the signature recorded by this delivery.
+func (handler *Handler) release(nonce string, newNonce bool, signature string, newSignature bool) {
+  if newNonce {
+    handler.nonces.Remove(nonce)
+  }
+
+  if newSignature {
+    handler.nonces.Remove(signature)
+  }
+}
+
+func (handler *Handler) fresh(date time.Time) bool {
+  skew := handler.now().Sub(date)
+  return skew <= handler.tolerance && skew >= -handler.tolerance
+}
+
+// verify - Checks the APIAuth signature and, when present, the Content-MD5 of the body.
+func (handler *Handler) verify(request *http.Request, body string, date time.Time) bool {
+  if md5 := request.Header.Get("Content-MD5"); md5 != "" && md5 != util.ContentMD5(body) {
+    return false
+  }
+
+  canonical := util.Canonical(request.Method, request.Header.Get("Content-Type"), body, request.URL.Path, date)
+  expected := "APIAuth " + handler.apiKey + ":" + util.Sign(handler.secretKey, canonical)
+  return hmac.Equal([]byte(strings.TrimSpace(request.Header.Get("Authorization"))), []byte(expected))
+}
+
+// Sign - Signs a delivery as Kueski does, setting the Date, Content-MD5 and Authorization headers.
+// Useful to test webhook receivers.
+func Sign(request *http.Request, body []byte, apiKey, secretKey string, now time.Time) {
+  request.Header.Set("Date", util.HTTPDate(now))
+  request.Header.Set("Content-MD5", util.ContentMD5(string(body)))
+
+  canonical := util.Canonical(request.Method, request.Header.Get("Content-Type"), string(body), request.URL.Path, now)
+  request.Header.Set("Authorization", "APIAuth "+apiKey+":"+util.Sign(secretKey, canonical))
+}
+
+// MemoryNonceStore - In memory NonceStore, expired nonces are pruned as new ones arrive.
+type MemoryNonceStore struct {
+  nonces map[string]time.Time
+  now    func() time.Time
+  sync.Mutex
+}
+
+// NewMemoryNonceStore - MemoryNonceStore constructor.
+func NewMemoryNonceStore() *MemoryNonceStore {
+  return &MemoryNonceStore{nonces: map[string]time.Time{}, now: time.Now}
+}
+
+// Add - Records the nonce until expiration, returning false if it was already recorded.
+func (store *MemoryNonceStore) Add(nonce string, expiration time.Time) bool {
+  store.Lock()
+  defer store.Unlock()
+
+  now := store.now()
+
+  for seen, expires := range store.nonces {
+    if now.After(expires) {
+      delete(store.nonces, seen)
+    }
+  }
+
+  if _, seen := store.nonces[nonce]; seen {
+    return false
+  }
+
+  store.nonces[nonce] = expiration
+  return true
+}
+
+// Remove - Forgets the nonce.
+func (store *MemoryNonceStore) Remove(nonce string) {
+  store.Lock()
+  defer store.Unlock()
+  delete(store.nonces, nonce)
+}
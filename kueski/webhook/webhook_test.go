@@ -0,0 +1,103 @@
+package webhook
+
+import (
+  "bytes"
+  "net/http"
+  "net/http/httptest"
+  "testing"
+  "time"
+
+  "github.com/stretchr/testify/assert"
+)
+
+const delivery = `{ "id": "evt_1", "type": "lead.funded", "request_id": "42", "occurred_at": "2026-01-02T03:04:05Z" }`
+
+func signedRequest(body, nonce string, now time.Time) *http.Request {
+  request := httptest.NewRequest("POST", "/kueski/webhooks", bytes.NewBufferString(body))
+  request.Header.Set("Content-Type", "application/json")
+  request.Header.Set(NonceHeader, nonce)
+  Sign(request, []byte(body), "Key", "Secret", now)
+  return request
+}
+
+func serve(handler http.Handler, request *http.Request) int {
+  recorder := httptest.NewRecorder()
+  handler.ServeHTTP(recorder, request)
+  return recorder.Code
+}
+
+func TestHandler(t *testing.T) {
+  events := []Event{}
+  handler := NewHandler("Key", "Secret", func(event Event) error {
+    events = append(events, event)
+    return nil
+  }, Options{})
+
+  now := time.Now()
+  assert.Equal(t, http.StatusOK, serve(handler, signedRequest(delivery, "n1", now)))
+  assert.Equal(t, []Event{{"evt_1", EventFunded, "42", "", time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)}}, events)
+
+  assert.Equal(t, http.StatusConflict, serve(handler, signedRequest(delivery, "n1", now)))
+  assert.Equal(t, http.StatusConflict, serve(handler, signedRequest(delivery, "n2", now)))
+  assert.Equal(t, http.StatusUnauthorized, serve(handler, signedRequest(delivery, "n3", now.Add(-10*time.Minute))))
+  assert.Equal(t, http.StatusUnauthorized, serve(handler, signedRequest(delivery, "", now.Add(time.Second))))
+  assert.Equal(t, 1, len(events))
+}
+
+func TestHandlerRejections(t *testing.T) {
+  handler := NewHandler("Key", "Secret", func(event Event) error { return nil }, Options{Tolerance: time.Minute})
+  now := time.Now()
+
+  tampered := signedRequest(delivery, "n1", now)
+  tampered.Body = httptest.NewRequest("POST", "/", bytes.NewBufferString(`{ "type": "lead.approved" }`)).Body
+  assert.Equal(t, http.StatusUnauthorized, serve(handler, tampered))
+
+  otherKey := signedRequest(delivery, "n2", now)
+  Sign(otherKey, []byte(delivery), "Key", "Other", now)
+  assert.Equal(t, http.StatusUnauthorized, serve(handler, otherKey))
+
+  assert.Equal(t, http.StatusBadRequest, serve(handler, signedRequest(`{ "type": "lead.lost", "request_id": "42" }`, "n3", now)))
+  assert.Equal(t, http.StatusMethodNotAllowed, serve(handler, httptest.NewRequest("GET", "/", nil)))
+
+  failing := NewHandler("Key", "Secret", func(event Event) error { return assert.AnError }, Options{})
+  assert.Equal(t, http.StatusInternalServerError, serve(failing, signedRequest(delivery, "n4", now)))
+}
+
+func TestHandlerRetryAfterFailure(t *testing.T) {
+  failures := 1
+  events := []Event{}
+  handler := NewHandler("Key", "Secret", func(event Event) error {
+    if failures > 0 {
+      failures--
+      return assert.AnError
+    }
+
+    events = append(events, event)
+    return nil
+  }, Options{})
+
+  now := time.Now()
+  assert.Equal(t, http.StatusInternalServerError, serve(handler, signedRequest(delivery, "n1", now)))
+  assert.Equal(t, http.StatusOK, serve(handler, signedRequest(delivery, "n1", now)))
+  assert.Equal(t, 1, len(events))
+  assert.Equal(t, http.StatusConflict, serve(handler, signedRequest(delivery, "n1", now)))
+
+  assert.Equal(t, http.StatusConflict, serve(handler, signedRequest(delivery, "n2", now)))
+  assert.Equal(t, http.StatusOK, serve(handler, signedRequest(delivery, "n2", now.Add(time.Second))))
+  assert.Equal(t, 2, len(events))
+}
+
+func TestMemoryNonceStore(t *testing.T) {
+  store := NewMemoryNonceStore()
+  now := time.Now()
+  store.now = func() time.Time { return now }
+
+  assert.True(t, store.Add("a", now.Add(time.Minute)))
+  assert.False(t, store.Add("a", now.Add(time.Minute)))
+
+  store.Remove("a")
+  assert.True(t, store.Add("a", now.Add(time.Minute)))
+
+  now = now.Add(2 * time.Minute)
+  assert.True(t, store.Add("a", now.Add(time.Minute)))
+}